@@ -2,7 +2,4 @@ module github.com/BaxtiyorUrolov/go-mfcc
 
 go 1.23.8
 
-require (
-	github.com/DylanMeeus/GoAudio v0.13.1
-	github.com/mjibson/go-dsp v0.0.0-20180508042940-11479a337f12
-)
+require github.com/mjibson/go-dsp v0.0.0-20180508042940-11479a337f12
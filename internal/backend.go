@@ -0,0 +1,126 @@
+package internal
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// O‘rnatilgan backend nomlari
+const (
+	BackendCPU  = "cpu"  // Toza Go’dagi etalon amalga oshirish
+	BackendCUDA = "cuda" // CUDA orqali GPU’da hisoblash (`cuda` build tegi)
+)
+
+// ErrUnknownBackend - Ro‘yxatdan o‘tmagan backend nomi so‘ralganda qaytariladi
+var ErrUnknownBackend = errors.New("noma’lum backend")
+
+// BackendResult - Backend ramkalar to‘plami uchun hisoblagan natijalar.
+// Har bir maydon kirish ramkalari soniga teng uzunlikdagi massiv bo‘lishi kerak.
+type BackendResult struct {
+	PowerSpectra [][]float32 // Har bir ramkaning power spectrumi (FrameLength/2+1)
+	MelEnergies  [][]float32 // Mel filtrlar energiyalari (NumFilters)
+	MFCC         [][]float32 // MFCC koeffitsientlari (NumCoefficients)
+}
+
+// BackendSpec - Backend yaratish uchun kerakli parametrlar
+type BackendSpec struct {
+	Config      Config        // Protsessor konfiguratsiyasi
+	FilterBanks [][]float32   // Oldindan yaratilgan Mel filtrlar banki (zich, FFTLength/2+1 kenglikda)
+	MelFilters  MelFilterBank // FilterBanks ning siyrak ko‘rinishi (nil bo‘lsa FilterBanks dan quriladi)
+	DCT         *DCTMatrix    // Oldindan hisoblangan DCT bazisi (nil bo‘lsa Config dan quriladi)
+}
+
+// melFilters - Siyrak Mel filtrlar banki (protsessor bermagan bo‘lsa FilterBanks dan quriladi)
+func (s BackendSpec) melFilters() MelFilterBank {
+	if s.MelFilters != nil {
+		return s.MelFilters
+	}
+	return NewMelFilterBank(s.FilterBanks)
+}
+
+// dctMatrix - DCT bazisi (protsessor bermagan bo‘lsa Config dan quriladi)
+func (s BackendSpec) dctMatrix() *DCTMatrix {
+	if s.DCT != nil {
+		return s.DCT
+	}
+	return NewDCTMatrix(len(s.FilterBanks), s.Config.NumCoefficients)
+}
+
+// Backend - Ramkalar to‘plami ustida power spectrum, Mel filtrlash, log va DCT bosqichlarini bajaradi.
+// Kirish ramkalariga oyna funksiyasi oldindan qo‘llangan bo‘ladi. Compute bir vaqtning o‘zida
+// bir nechta goroutindan chaqirilishi mumkin, shuning uchun amalga oshirishlar buni hisobga olishi kerak.
+type Backend interface {
+	Name() string                                       // Backend nomi
+	Compute(frames [][]float32) (*BackendResult, error) // Ramkalar to‘plamini qayta ishlash
+	Close() error                                       // Resurslarni ozod qilish
+}
+
+// BackendFactory - Berilgan parametrlar bo‘yicha yangi backend yaratuvchi funksiya
+type BackendFactory func(spec BackendSpec) (Backend, error)
+
+var (
+	backendsMu sync.RWMutex
+	backends   = make(map[string]BackendFactory)
+)
+
+// RegisterBackend - Yangi backend’ni nomi bilan ro‘yxatdan o‘tkazish
+func RegisterBackend(name string, factory BackendFactory) error {
+	if name == "" {
+		return errors.New("backend nomi bo‘sh bo‘lmasligi kerak")
+	}
+	if factory == nil {
+		return errors.New("backend yaratuvchi funksiya nil bo‘lmasligi kerak")
+	}
+
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	if _, ok := backends[name]; ok {
+		return fmt.Errorf("%q backend allaqachon ro‘yxatdan o‘tgan", name)
+	}
+	backends[name] = factory
+	return nil
+}
+
+// Backends - Ro‘yxatdan o‘tgan backend nomlarini tartiblangan holda qaytarish
+func Backends() []string {
+	backendsMu.RLock()
+	defer backendsMu.RUnlock()
+	names := make([]string, 0, len(backends))
+	for name := range backends {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// newBackend - Ro‘yxatdan nom bo‘yicha backend yaratish
+func newBackend(name string, spec BackendSpec) (Backend, error) {
+	backendsMu.RLock()
+	factory, ok := backends[name]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrUnknownBackend, name)
+	}
+	return factory(spec)
+}
+
+// backendName - Konfiguratsiya bo‘yicha ishlatiladigan backend nomini aniqlash
+func backendName(cfg Config) string {
+	switch {
+	case cfg.Backend != "":
+		return cfg.Backend
+	case cfg.UseGPU:
+		return BackendCUDA
+	default:
+		return BackendCPU
+	}
+}
+
+// mustRegisterBackend - O‘rnatilgan backend’larni init vaqtida ro‘yxatdan o‘tkazish
+func mustRegisterBackend(name string, factory BackendFactory) {
+	if err := RegisterBackend(name, factory); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,81 @@
+package internal
+
+func init() {
+	mustRegisterBackend(BackendCPU, NewCPUBackend)
+}
+
+// cpuBackend - Toza Go’dagi etalon backend
+type cpuBackend struct {
+	config     Config
+	melFilters MelFilterBank
+	dct        *DCTMatrix
+	lifter     []float32 // Kepstral lifter koeffitsientlari (CepLifter > 0 bo‘lsa)
+	fft        *RealFFT  // Konfiguratsiyadagi FFT uzunligi uchun oldindan hisoblangan reja
+	workspaces *workspacePool
+}
+
+// NewCPUBackend - Yangi CPU backend yaratish
+func NewCPUBackend(spec BackendSpec) (Backend, error) {
+	cfg := spec.Config
+	plan := NewRealFFT(cfg.fftLength())
+	return &cpuBackend{
+		config:     cfg,
+		melFilters: spec.melFilters(),
+		dct:        spec.dctMatrix(),
+		lifter:     createLifter(cfg.NumCoefficients, cfg.CepLifter),
+		fft:        plan,
+		workspaces: newWorkspacePool(plan.BufferLen(), cfg.NumFilters),
+	}, nil
+}
+
+// Name - Backend nomini qaytaradi
+func (b *cpuBackend) Name() string {
+	return BackendCPU
+}
+
+// Compute - Ramkalar to‘plamini ketma-ket qayta ishlaydi.
+// Bir nechta goroutine’dan parallel chaqirish xavfsiz: har bir chaqiruv havzadan alohida workspace oladi.
+func (b *cpuBackend) Compute(frames [][]float32) (*BackendResult, error) {
+	res := &BackendResult{
+		PowerSpectra: make([][]float32, len(frames)),
+		MelEnergies:  make([][]float32, len(frames)),
+		MFCC:         make([][]float32, len(frames)),
+	}
+	ws := b.workspaces.get()
+	defer b.workspaces.put(ws)
+	for i, frame := range frames {
+		res.PowerSpectra[i], res.MelEnergies[i], res.MFCC[i] = b.computeFrame(frame, ws)
+	}
+	return res, nil
+}
+
+// computeFrame - Bitta ramka uchun power spectrum, Mel energiyalari va MFCC ni hisoblash.
+// Natijalar yangi slice’larga yoziladi, ws dan faqat oraliq qiymatlar uchun foydalaniladi.
+func (b *cpuBackend) computeFrame(frame []float32, ws *workspace) (power, mel, mfcc []float32) {
+	// Power spectrumini hisoblash
+	plan := b.fftPlan(len(frame))
+	power = computePowerSpectrum(frame, plan, ws.fftBuffer(plan.BufferLen()))
+	// Mel energiyalarini hisoblash
+	mel = b.melFilters.Apply(power, make([]float32, len(b.melFilters)))
+	// Logarifmik shkalaga o‘tkazish
+	logMelEnergies := applyLogMode(mel, ws.logBuffer(len(mel)), b.config.LogMode)
+	// DCT ni qo‘llash va MFCC chiqarish
+	mfcc = b.dct.Apply(logMelEnergies, make([]float32, b.config.NumCoefficients))
+	// Lifterni qo‘llash
+	applyLifter(mfcc, b.lifter)
+	return power, mel, mfcc
+}
+
+// fftPlan - n uzunlikdagi ramka uchun FFT rejasi. Protsessor ramkalari doim konfiguratsiya uzunligida bo‘ladi;
+// Compute ni boshqa uzunlikdagi ramkalar bilan to‘g‘ridan-to‘g‘ri chaqirilganda reja joyida yaratiladi.
+func (b *cpuBackend) fftPlan(n int) *RealFFT {
+	if n == b.fft.Len() {
+		return b.fft
+	}
+	return NewRealFFT(max(n, 1))
+}
+
+// Close - CPU backend’da ozod qilinadigan resurs yo‘q
+func (b *cpuBackend) Close() error {
+	return nil
+}
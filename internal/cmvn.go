@@ -0,0 +1,313 @@
+package internal
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+)
+
+// CMVNMode - Kepstral o‘rtacha va dispersiya normalizatsiyasi (CMVN) turi
+type CMVNMode string
+
+const (
+	CMVNNone      CMVNMode = "none"      // Normalizatsiya yo‘q
+	CMVNUtterance CMVNMode = "utterance" // Butun audio bo‘yicha har bir koeffitsient uchun
+	CMVNSliding   CMVNMode = "sliding"   // Sirpanuvchi oyna (Kaldi `apply-cmvn-sliding` uslubida)
+	CMVNGlobal    CMVNMode = "global"    // Dataset bo‘yicha yig‘ilgan global statistika
+)
+
+// cmvnMinWindow - Sirpanuvchi CMN da markazlanmagan oynaning minimal uzunligi (Kaldi standarti)
+const cmvnMinWindow = 100
+
+// cmvnVarFloor - Bundan kichik dispersiya uchun masshtablash qo‘llanmaydi
+const cmvnVarFloor = 1e-20
+
+// ErrNoCMVNStats - Global CMVN tanlangan, lekin statistika berilmagan
+var ErrNoCMVNStats = errors.New("global CMVN uchun statistika berilmagan")
+
+// CMVNStats - Dataset bo‘yicha yig‘iladigan birinchi va ikkinchi tartibli statistika
+type CMVNStats struct {
+	Count int64     `json:"count"`  // Yig‘ilgan ramkalar soni
+	Sum   []float64 `json:"sum"`    // Har bir koeffitsient yig‘indisi
+	SumSq []float64 `json:"sum_sq"` // Har bir koeffitsient kvadratlari yig‘indisi
+}
+
+// NewCMVNStats - Berilgan o‘lchamdagi bo‘sh statistika yaratish
+func NewCMVNStats(dim int) *CMVNStats {
+	return &CMVNStats{
+		Sum:   make([]float64, dim),
+		SumSq: make([]float64, dim),
+	}
+}
+
+// Dim - Statistika o‘lchamini qaytaradi
+func (s *CMVNStats) Dim() int {
+	return len(s.Sum)
+}
+
+// Accumulate - Ramkalarni statistikaga qo‘shish
+func (s *CMVNStats) Accumulate(frames [][]float32) error {
+	for i, frame := range frames {
+		if len(frame) != s.Dim() {
+			return fmt.Errorf("ramka %d o‘lchami %d, statistika o‘lchami %d", i, len(frame), s.Dim())
+		}
+		for k, val := range frame {
+			v := float64(val)
+			s.Sum[k] += v
+			s.SumSq[k] += v * v
+		}
+		s.Count++
+	}
+	return nil
+}
+
+// Merge - Boshqa statistikani (masalan, boshqa worker’dan) qo‘shish
+func (s *CMVNStats) Merge(other *CMVNStats) error {
+	if other.Dim() != s.Dim() {
+		return fmt.Errorf("statistika o‘lchamlari mos emas: %d != %d", other.Dim(), s.Dim())
+	}
+	for k := range s.Sum {
+		s.Sum[k] += other.Sum[k]
+		s.SumSq[k] += other.SumSq[k]
+	}
+	s.Count += other.Count
+	return nil
+}
+
+// Save - Statistikani JSON faylga saqlash
+func (s *CMVNStats) Save(filename string) error {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return fmt.Errorf("CMVN statistikasini kodlashda xatolik: %w", err)
+	}
+	if err := os.WriteFile(filename, data, 0o644); err != nil {
+		return fmt.Errorf("CMVN statistikasini saqlashda xatolik: %w", err)
+	}
+	return nil
+}
+
+// LoadCMVNStats - JSON fayldan statistikani o‘qish
+func LoadCMVNStats(filename string) (*CMVNStats, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, fmt.Errorf("CMVN statistikasini o‘qishda xatolik: %w", err)
+	}
+	var s CMVNStats
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, fmt.Errorf("CMVN statistikasini dekodlashda xatolik: %w", err)
+	}
+	if len(s.Sum) != len(s.SumSq) {
+		return nil, errors.New("CMVN statistikasi buzilgan: sum va sum_sq o‘lchamlari mos emas")
+	}
+	return &s, nil
+}
+
+// apply - Global statistika bilan ramkalarni joyida normallashtirish
+func (s *CMVNStats) apply(frames [][]float32, normVars bool) error {
+	if s.Count == 0 {
+		return errors.New("CMVN statistikasi bo‘sh")
+	}
+	n := float64(s.Count)
+	mean := make([]float64, s.Dim())
+	scale := make([]float64, s.Dim())
+	for k := range mean {
+		mean[k] = s.Sum[k] / n
+		scale[k] = varianceScale(s.SumSq[k]/n-mean[k]*mean[k], normVars)
+	}
+	for i, frame := range frames {
+		if len(frame) != s.Dim() {
+			return fmt.Errorf("ramka %d o‘lchami %d, statistika o‘lchami %d", i, len(frame), s.Dim())
+		}
+		for k := range frame {
+			frame[k] = float32((float64(frame[k]) - mean[k]) * scale[k])
+		}
+	}
+	return nil
+}
+
+// utteranceCMVN - Butun audio bo‘yicha har bir koeffitsientni joyida normallashtirish
+func utteranceCMVN(frames [][]float32, normVars bool) {
+	if len(frames) == 0 {
+		return
+	}
+	stats := NewCMVNStats(len(frames[0]))
+	if err := stats.Accumulate(frames); err != nil {
+		return
+	}
+	stats.apply(frames, normVars)
+}
+
+// slidingCMVN - Sirpanuvchi oyna bilan joyida normallashtirish (Kaldi `apply-cmvn-sliding`).
+// center=false bo‘lsa oyna joriy ramkada tugaydi, aks holda uning atrofida markazlanadi.
+func slidingCMVN(frames [][]float32, window int, center, normVars bool) {
+	numFrames := len(frames)
+	if numFrames == 0 {
+		return
+	}
+	dim := len(frames[0])
+
+	// Prefiks yig‘indilar: har bir oyna statistikasi O(dim) da olinadi
+	prefix := make([][]float64, numFrames+1)
+	prefixSq := make([][]float64, numFrames+1)
+	prefix[0] = make([]float64, dim)
+	prefixSq[0] = make([]float64, dim)
+	for t, frame := range frames {
+		prefix[t+1] = make([]float64, dim)
+		prefixSq[t+1] = make([]float64, dim)
+		for k := 0; k < dim; k++ {
+			v := float64(frame[k])
+			prefix[t+1][k] = prefix[t][k] + v
+			prefixSq[t+1][k] = prefixSq[t][k] + v*v
+		}
+	}
+
+	for t, frame := range frames {
+		start, end := slidingWindowBounds(t, numFrames, window, center)
+		n := float64(end - start)
+		for k := 0; k < dim; k++ {
+			mean := (prefix[end][k] - prefix[start][k]) / n
+			scale := 1.0
+			if normVars {
+				variance := (prefixSq[end][k]-prefixSq[start][k])/n - mean*mean
+				scale = varianceScale(variance, true)
+			}
+			frame[k] = float32((float64(frame[k]) - mean) * scale)
+		}
+	}
+}
+
+// slidingCMVNStream - Sirpanuvchi CMVN ni oqim bo‘yicha qo‘llash; natija slidingCMVN bilan bir xil.
+// t-ramka uning oynasidagi oxirgi ramka kelgandan keyin (yoki flush vaqtida) chiqariladi: markazlangan oynada
+// kechikish window/2 ramka, markazlanmaganida faqat dastlabki cmvnMinWindow ramka kutadi.
+// Xotirada kutayotgan ramkalar va oyna uzunligidagi prefiks yig‘indilar saqlanadi.
+type slidingCMVNStream struct {
+	window   int
+	center   bool
+	normVars bool
+	pending  []FrameFeatures // Hali chiqarilmagan ramkalar, pending[0] - next-ramka
+	prefix   [][]float64     // prefix[i] - base+i dan oldingi barcha ramkalar yig‘indisi
+	prefixSq [][]float64     // Kvadratlar uchun xuddi shunday
+	base     int             // prefix[0] ning absolyut indeksi
+	next     int             // Chiqariladigan keyingi ramka indeksi
+	total    int             // Qabul qilingan ramkalar soni
+}
+
+// newSlidingCMVNStream - Yangi oqimli sirpanuvchi CMVN yaratish
+func newSlidingCMVNStream(window int, center, normVars bool) *slidingCMVNStream {
+	return &slidingCMVNStream{window: window, center: center, normVars: normVars}
+}
+
+// push - Normallashtirilmagan ramkani qo‘shish va oynasi to‘liq kelgan ramkalarni qaytarish
+func (s *slidingCMVNStream) push(f FrameFeatures) []FrameFeatures {
+	dim := len(f.MFCC)
+	if len(s.prefix) == 0 {
+		s.prefix = append(s.prefix, make([]float64, dim))
+		s.prefixSq = append(s.prefixSq, make([]float64, dim))
+	}
+	last, lastSq := s.prefix[len(s.prefix)-1], s.prefixSq[len(s.prefixSq)-1]
+	sum, sumSq := make([]float64, dim), make([]float64, dim)
+	for k, val := range f.MFCC {
+		v := float64(val)
+		sum[k] = last[k] + v
+		sumSq[k] = lastSq[k] + v*v
+	}
+	s.prefix = append(s.prefix, sum)
+	s.prefixSq = append(s.prefixSq, sumSq)
+	s.pending = append(s.pending, f)
+	s.total++
+
+	var ready []FrameFeatures
+	for s.next < s.total {
+		// Oyna signal oxiriga yetmasa, uning chegaralari ramkalar umumiy soniga bog‘liq emas
+		start, end := slidingWindowBounds(s.next, math.MaxInt, s.window, s.center)
+		if end > s.total {
+			break
+		}
+		ready = append(ready, s.emit(start, end))
+	}
+	s.trim()
+	return ready
+}
+
+// flush - Signal oxirida qolgan ramkalarni oxirgi ramkagacha qisqargan oyna bilan chiqarish
+func (s *slidingCMVNStream) flush() []FrameFeatures {
+	var ready []FrameFeatures
+	for s.next < s.total {
+		start, end := slidingWindowBounds(s.next, s.total, s.window, s.center)
+		ready = append(ready, s.emit(start, end))
+	}
+	return ready
+}
+
+// emit - next-ramkani [start, end) oyna statistikasi bilan normallashtirib qaytarish
+func (s *slidingCMVNStream) emit(start, end int) FrameFeatures {
+	f := s.pending[0]
+	s.pending = s.pending[1:]
+	s.next++
+
+	lo, hi := s.prefix[start-s.base], s.prefix[end-s.base]
+	loSq, hiSq := s.prefixSq[start-s.base], s.prefixSq[end-s.base]
+	n := float64(end - start)
+	mfcc := make([]float32, len(f.MFCC))
+	for k := range mfcc {
+		mean := (hi[k] - lo[k]) / n
+		scale := 1.0
+		if s.normVars {
+			variance := (hiSq[k]-loSq[k])/n - mean*mean
+			scale = varianceScale(variance, true)
+		}
+		mfcc[k] = float32((float64(f.MFCC[k]) - mean) * scale)
+	}
+	f.MFCC = mfcc
+	return f
+}
+
+// trim - Keyingi ramkalar oynasiga kirmaydigan prefiks yig‘indilarni tashlash.
+// Qisqa signal oxirida oyna orqaga surilishi mumkin, shuning uchun max(window, cmvnMinWindow) ramka saqlanadi.
+func (s *slidingCMVNStream) trim() {
+	keep := s.next - max(s.window, cmvnMinWindow)
+	if drop := keep - s.base; drop > 0 {
+		s.prefix = append(s.prefix[:0], s.prefix[drop:]...)
+		s.prefixSq = append(s.prefixSq[:0], s.prefixSq[drop:]...)
+		s.base = keep
+	}
+}
+
+// slidingWindowBounds - t-ramka uchun oyna chegaralarini [start, end) aniqlash (Kaldi mantiqi)
+func slidingWindowBounds(t, numFrames, window int, center bool) (start, end int) {
+	if center {
+		start = t - window/2
+		end = start + window
+	} else {
+		start = t - window
+		end = t + 1
+	}
+	if start < 0 {
+		end -= start
+		start = 0
+	}
+	if !center && end > t {
+		end = t + 1
+		if end < cmvnMinWindow {
+			end = cmvnMinWindow
+		}
+	}
+	if end > numFrames {
+		start -= end - numFrames
+		end = numFrames
+		if start < 0 {
+			start = 0
+		}
+	}
+	return start, end
+}
+
+// varianceScale - Dispersiyadan masshtab koeffitsientini hisoblash
+func varianceScale(variance float64, normVars bool) float64 {
+	if !normVars || variance < cmvnVarFloor {
+		return 1
+	}
+	return 1 / math.Sqrt(variance)
+}
@@ -13,23 +13,55 @@ const (
 	Hanning  WindowType = "hanning"     // Hanning oynasi turi
 	Blackman WindowType = "blackman"    // Blackman oynasi turi
 	Rect     WindowType = "rectangular" // To‘rtburchak oynasi turi
+	Povey    WindowType = "povey"       // Povey oynasi (Kaldi standarti): Hanning^0.85
 )
 
 // Config - MFCC hisoblash konfiguratsiyasi uchun tuzilma
 // JSON teglari orqali konfiguratsiyani tashqi fayllardan yuklab olish mumkin
 type Config struct {
-	SampleRate      int        `json:"sample_rate"`      // Audio namunalar tezligi (Hz)
-	FrameLength     int        `json:"frame_length"`     // Har bir ramkaning uzunligi (namunalar soni)
-	HopLength       int        `json:"hop_length"`       // Ramkalar orasidagi qadam uzunligi
-	NumCoefficients int        `json:"num_coefficients"` // MFCC koeffitsientlari soni
-	NumFilters      int        `json:"num_filters"`      // Mel filtrlar banki soni
-	WindowType      WindowType `json:"window_type"`      // Ishlatiladigan oyna turi
-	PreEmphasis     float32    `json:"pre_emphasis"`     // Pre-emphasis koeffitsienti
-	UseGPU          bool       `json:"use_gpu"`          // GPU ishlatishni yoqish/o‘chirish
-	Parallel        bool       `json:"parallel"`         // Parallel hisoblashni yoqish/o‘chirish
-	MaxConcurrency  int        `json:"max_concurrency"`  // Maksimal parallel goroutinlar soni
-	LowFreq         float32    `json:"low_freq"`         // Mel filtrlar uchun past chastota chegarasi (Hz)
-	HighFreq        float32    `json:"high_freq"`        // Mel filtrlar uchun yuqori chastota chegarasi (Hz)
+	SampleRate       int             `json:"sample_rate"`        // Audio namunalar tezligi (Hz)
+	FrameLength      int             `json:"frame_length"`       // Har bir ramkaning uzunligi (namunalar soni)
+	HopLength        int             `json:"hop_length"`         // Ramkalar orasidagi qadam uzunligi
+	NumCoefficients  int             `json:"num_coefficients"`   // MFCC koeffitsientlari soni
+	NumFilters       int             `json:"num_filters"`        // Mel filtrlar banki soni
+	WindowType       WindowType      `json:"window_type"`        // Ishlatiladigan oyna turi
+	PreEmphasis      float32         `json:"pre_emphasis"`       // Pre-emphasis koeffitsienti
+	UseGPU           bool            `json:"use_gpu"`            // GPU ishlatishni yoqish/o‘chirish
+	Parallel         bool            `json:"parallel"`           // Parallel hisoblashni yoqish/o‘chirish
+	MaxConcurrency   int             `json:"max_concurrency"`    // Maksimal parallel goroutinlar soni
+	LowFreq          float32         `json:"low_freq"`           // Mel filtrlar uchun past chastota chegarasi (Hz)
+	HighFreq         float32         `json:"high_freq"`          // Mel filtrlar uchun yuqori chastota chegarasi (Hz)
+	Backend          string          `json:"backend"`            // Hisoblash backend’i nomi (bo‘sh bo‘lsa UseGPU ga qarab tanlanadi)
+	BackendFallback  bool            `json:"backend_fallback"`   // Backend xatolik bersa CPU’ga o‘tish
+	DeltaOrder       int             `json:"delta_order"`        // Delta tartibi: 0 - yo‘q, 1 - Δ, 2 - Δ va ΔΔ
+	DeltaWindow      int             `json:"delta_window"`       // Delta regressiya oynasi yarim kengligi (N)
+	CMVN             CMVNMode        `json:"cmvn"`               // CMVN turi (bo‘sh bo‘lsa normalizatsiya yo‘q)
+	CMVNNormVars     bool            `json:"cmvn_norm_vars"`     // Dispersiyani ham normallashtirish
+	CMVNWindow       int             `json:"cmvn_window"`        // Sirpanuvchi CMVN oynasi uzunligi (ramkalar)
+	CMVNCenter       bool            `json:"cmvn_center"`        // Sirpanuvchi oynani joriy ramka atrofida markazlash
+	CMVNStatsFile    string          `json:"cmvn_stats_file"`    // Global CMVN statistikasi fayli
+	CepLifter        int             `json:"cep_lifter"`         // Kepstral lifter parametri L (0 - o‘chirilgan, HTK/Kaldi standarti 22)
+	MelScale         MelScale        `json:"mel_scale"`          // Mel shkalasi formulasi: "htk" (standart), "slaney" yoki "kaldi"
+	MelNorm          MelNorm         `json:"mel_norm"`           // Mel filtrlarini normallashtirish: "none", "slaney", "l1" yoki "l2"
+	PeriodicWindow   bool            `json:"periodic_window"`    // Davriy oyna (N davr), librosa/scipy `fftbins=True`
+	Padding          PaddingMode     `json:"padding"`            // Signal chegaralarini to‘ldirish: "none" (standart), "tail", "center" yoki "kaldi"
+	PadMode          PadMode         `json:"pad_mode"`           // To‘ldirish qiymatlari: "reflect" (standart), "constant", "symmetric" yoki "edge"
+	LogMode          LogMode         `json:"log_mode"`           // Logarifm turi: "natural" (standart), "db" yoki "kaldi"
+	TopDB            float32         `json:"top_db"`             // dB rejimida maksimumdan pastdagi kesish chegarasi (0 - o‘chirilgan)
+	FFTSize          int             `json:"fft_size"`           // FFT uzunligi (0 bo‘lsa FrameLength); ramka oxiri nollar bilan to‘ldiriladi
+	SampleScale      float32         `json:"sample_scale"`       // Kirish namunalari ko‘paytuvchisi (0 - o‘zgarishsiz, Kaldi int16 shkalasi uchun 32768)
+	Dither           float32         `json:"dither"`             // Har bir ramkaga qo‘shiladigan Gauss shovqini amplitudasi (0 - o‘chirilgan)
+	RemoveDCOffset   bool            `json:"remove_dc_offset"`   // Har bir ramkadan o‘rtacha qiymatni ayirish
+	FramePreEmphasis bool            `json:"frame_pre_emphasis"` // Pre-emphasis ni butun signalga emas, har bir ramkaga alohida qo‘llash (Kaldi)
+	UseEnergy        bool            `json:"use_energy"`         // C0 o‘rniga ramkaning log energiyasini yozish
+	RawEnergy        bool            `json:"raw_energy"`         // Energiyani pre-emphasis va oynadan oldin hisoblash
+	EnergyFloor      float32         `json:"energy_floor"`       // Log energiya uchun pastki chegara (0 - o‘chirilgan)
+	ResampleQuality  ResampleQuality `json:"resample_quality"`   // Boshqa tezlikdagi audioni SampleRate ga o‘tkazish sifati (bo‘sh bo‘lsa "high")
+	PitchMethod      PitchMethod     `json:"pitch_method"`       // Pitch algoritmi: "yin" (standart) yoki "pyin"
+	PitchFmin        float32         `json:"pitch_fmin"`         // Minimal f0 (Hz, 0 bo‘lsa max(50, 2·SampleRate/FrameLength))
+	PitchFmax        float32         `json:"pitch_fmax"`         // Maksimal f0 (Hz, 0 bo‘lsa min(400, SampleRate/4))
+	PitchThreshold   float32         `json:"pitch_threshold"`    // YIN absolyut chegarasi (0 bo‘lsa 0.1)
+	Features         FeatureSet      `json:"features"`           // ProcessFeatures hisoblaydigan xususiyatlar (0 bo‘lsa barchasi)
 }
 
 // Validate - Konfiguratsiyani tekshirish funksiyasi
@@ -49,28 +81,100 @@ func (c *Config) Validate() error {
 	if c.NumFilters <= 0 { // Filtrlar soni musbat bo‘lishi kerak
 		return errors.New("number of filters must be positive")
 	}
+	if c.NumCoefficients > c.NumFilters { // DCT bazisi filtrlar sonidan ortiq mustaqil qator bermaydi
+		return fmt.Errorf("number of coefficients (%d) must not exceed number of filters (%d)", c.NumCoefficients, c.NumFilters)
+	}
+	switch c.WindowType { // Oyna turi ma’lum bo‘lishi kerak (bo‘sh - to‘rtburchak oyna)
+	case "", Hamming, Hanning, Blackman, Rect, Povey:
+	default:
+		return fmt.Errorf("unknown window type %q", c.WindowType)
+	}
 	if c.PreEmphasis < 0 || c.PreEmphasis >= 1 { // Pre-emphasis [0, 1) oralig‘ida bo‘lishi kerak
 		return errors.New("pre-emphasis coefficient must be in [0, 1) range")
 	}
 	if c.MaxConcurrency < 1 { // Maksimal goroutinlar soni kamida 1 bo‘lishi kerak
 		return errors.New("max concurrency must be at least 1")
 	}
+	if c.DeltaOrder < 0 || c.DeltaOrder > 2 { // Delta tartibi 0, 1 yoki 2 bo‘lishi kerak
+		return errors.New("delta order must be 0, 1 or 2")
+	}
+	if c.DeltaOrder > 0 && c.DeltaWindow < 1 { // Delta oynasi musbat bo‘lishi kerak
+		return errors.New("delta window must be at least 1")
+	}
+	if err := validateMelOptions(c.MelScale, c.MelNorm); err != nil { // Mel sozlamalari ma’lum bo‘lishi kerak
+		return err
+	}
+	if err := validatePadding(c.Padding, c.PadMode); err != nil { // To‘ldirish sozlamalari ma’lum bo‘lishi kerak
+		return err
+	}
+	switch c.LogMode { // Logarifm turi ma’lum bo‘lishi kerak
+	case "", LogNatural, LogDB, LogKaldi:
+	default:
+		return fmt.Errorf("unknown log mode %q", c.LogMode)
+	}
+	if c.TopDB < 0 { // top_db manfiy bo‘lmasligi kerak
+		return errors.New("top_db must be non-negative")
+	}
+	if c.FFTSize != 0 && c.FFTSize < c.FrameLength { // FFT ramkani to‘liq qamrashi kerak
+		return errors.New("fft size must be zero or at least frame length")
+	}
+	if c.SampleScale < 0 { // Shkala manfiy bo‘lmasligi kerak
+		return errors.New("sample scale must be non-negative")
+	}
+	if c.Dither < 0 { // Dither manfiy bo‘lmasligi kerak
+		return errors.New("dither must be non-negative")
+	}
+	if c.EnergyFloor < 0 { // Energiya chegarasi manfiy bo‘lmasligi kerak
+		return errors.New("energy floor must be non-negative")
+	}
+	if err := validateResampleQuality(c.ResampleQuality); err != nil { // Resample sifati ma’lum bo‘lishi kerak
+		return err
+	}
+	if err := validatePitchOptions(c); err != nil { // Pitch sozlamalari ramka uzunligiga mos bo‘lishi kerak
+		return err
+	}
+	if err := validateFeatures(c.Features); err != nil { // Xususiyatlar maskasi ma’lum bitlardan iborat bo‘lishi kerak
+		return err
+	}
+	if c.CepLifter < 0 { // Lifter manfiy bo‘lmasligi kerak
+		return errors.New("cepstral lifter must be non-negative")
+	}
+	switch c.CMVN { // CMVN turi ma’lum bo‘lishi kerak
+	case "", CMVNNone, CMVNUtterance, CMVNGlobal:
+	case CMVNSliding:
+		if c.CMVNWindow < 1 {
+			return errors.New("cmvn window must be at least 1")
+		}
+	default:
+		return fmt.Errorf("unknown cmvn mode %q", c.CMVN)
+	}
 	return nil
 }
 
 // DefaultConfig - Standart konfiguratsiyani qaytarish
 func DefaultConfig() Config {
 	return Config{
-		SampleRate:      16000,   // Standart namunalar tezligi 16 kHz
-		FrameLength:     512,     // Standart ramka uzunligi 512 namunalar
-		HopLength:       256,     // Standart qadam uzunligi 256 namunalar
-		NumCoefficients: 13,      // Standart koeffitsientlar soni 13
-		NumFilters:      26,      // Standart filtrlar soni 26
-		WindowType:      Hamming, // Standart oyna turi Hamming
-		PreEmphasis:     0.97,    // Standart pre-emphasis koeffitsienti 0.97
-		Parallel:        true,    // Parallel hisoblash yoqilgan
-		MaxConcurrency:  4,       // Maksimal 4 goroutin
+		SampleRate:      16000,    // Standart namunalar tezligi 16 kHz
+		FrameLength:     512,      // Standart ramka uzunligi 512 namunalar
+		HopLength:       256,      // Standart qadam uzunligi 256 namunalar
+		NumCoefficients: 13,       // Standart koeffitsientlar soni 13
+		NumFilters:      26,       // Standart filtrlar soni 26
+		WindowType:      Hamming,  // Standart oyna turi Hamming
+		PreEmphasis:     0.97,     // Standart pre-emphasis koeffitsienti 0.97
+		Parallel:        true,     // Parallel hisoblash yoqilgan
+		MaxConcurrency:  4,        // Maksimal 4 goroutin
+		DeltaWindow:     2,        // Standart delta oynasi N=2
+		CMVN:            CMVNNone, // Standart holatda normalizatsiya yo‘q
+		CMVNWindow:      600,      // Kaldi standarti: 600 ramka (6 soniya)
+	}
+}
+
+// fftLength - Haqiqiy FFT uzunligini qaytarish (FFTSize berilmagan bo‘lsa FrameLength)
+func (c Config) fftLength() int {
+	if c.FFTSize > 0 {
+		return c.FFTSize
 	}
+	return c.FrameLength
 }
 
 // String - Konfiguratsiyani matn sifatida ko‘rish
@@ -0,0 +1,144 @@
+package internal
+
+// deltaComputer - Regressiya oynasi asosida delta va delta-delta koeffitsientlarini hisoblaydi.
+// Kaldi `add-deltas` usuli: har bir tartib uchun filtr oldingi tartib filtrining
+// [-N, N] regressiya oynasi bilan yig‘masi sifatida quriladi, chegaradagi ramkalar takrorlanadi.
+type deltaComputer struct {
+	order  int
+	window int
+	scales [][]float32 // scales[i] - i-tartib filtri, uzunligi 2*i*window+1
+}
+
+// newDeltaComputer - Berilgan tartib va oyna uchun delta hisoblagich yaratish
+func newDeltaComputer(order, window int) *deltaComputer {
+	scales := make([][]float32, order+1)
+	scales[0] = []float32{1}
+
+	var normalizer float32
+	for j := -window; j <= window; j++ {
+		normalizer += float32(j * j)
+	}
+
+	for i := 1; i <= order; i++ {
+		prev := scales[i-1]
+		prevOffset := (len(prev) - 1) / 2
+		curOffset := prevOffset + window
+		cur := make([]float32, 2*curOffset+1)
+		for j := -window; j <= window; j++ {
+			for k := -prevOffset; k <= prevOffset; k++ {
+				cur[j+k+curOffset] += float32(j) * prev[k+prevOffset] / normalizer
+			}
+		}
+		scales[i] = cur
+	}
+
+	return &deltaComputer{order: order, window: window, scales: scales}
+}
+
+// lookahead - Bitta ramka uchun kerak bo‘ladigan kelajak ramkalari soni
+func (d *deltaComputer) lookahead() int {
+	return d.order * d.window
+}
+
+// compute - t-ramka uchun delta va delta-delta ni hisoblash.
+// Massiv chegarasidan tashqaridagi indekslar eng yaqin ramkaga tenglashtiriladi.
+func (d *deltaComputer) compute(features []FrameFeatures, t int) (delta, deltaDelta []float32) {
+	out := make([][]float32, d.order+1)
+	for i := 1; i <= d.order; i++ {
+		scales := d.scales[i]
+		offset := (len(scales) - 1) / 2
+		result := make([]float32, len(features[t].MFCC))
+		for j, scale := range scales {
+			if scale == 0 {
+				continue
+			}
+			idx := t + j - offset
+			if idx < 0 {
+				idx = 0
+			} else if idx >= len(features) {
+				idx = len(features) - 1
+			}
+			for k, val := range features[idx].MFCC {
+				result[k] += scale * val
+			}
+		}
+		out[i] = result
+	}
+
+	if d.order >= 1 {
+		delta = out[1]
+	}
+	if d.order >= 2 {
+		deltaDelta = out[2]
+	}
+	return delta, deltaDelta
+}
+
+// apply - Butun ketma-ketlik uchun delta koeffitsientlarini to‘ldirish
+func (d *deltaComputer) apply(features []FrameFeatures) {
+	for t := range features {
+		features[t].Delta, features[t].DeltaDelta = d.compute(features, t)
+	}
+}
+
+// deltaStream - Oqim rejimida delta hisoblash uchun oldinga qarash buferi.
+// t-ramka faqat t+lookahead ramka kelgandan keyin (yoki flush vaqtida) chiqariladi.
+type deltaStream struct {
+	dc      *deltaComputer
+	history []FrameFeatures // Saqlangan ramkalar
+	start   int             // history[0] ning absolyut indeksi
+	next    int             // Chiqariladigan keyingi ramka indeksi
+}
+
+// newDeltaStream - Yangi oqim delta buferini yaratish
+func newDeltaStream(dc *deltaComputer) *deltaStream {
+	return &deltaStream{dc: dc}
+}
+
+// push - Yangi ramkani qo‘shish va tayyor bo‘lgan ramkalarni qaytarish
+func (s *deltaStream) push(f FrameFeatures) []FrameFeatures {
+	if s.dc == nil {
+		return []FrameFeatures{f}
+	}
+
+	s.history = append(s.history, f)
+	total := s.start + len(s.history)
+	lookahead := s.dc.lookahead()
+
+	var ready []FrameFeatures
+	for s.next+lookahead < total {
+		ready = append(ready, s.emit(s.next))
+		s.next++
+	}
+
+	// Endi kerak bo‘lmaydigan eski ramkalarni tashlab yuborish
+	if drop := s.next - lookahead - s.start; drop > 0 {
+		s.history = append(s.history[:0], s.history[drop:]...)
+		s.start += drop
+	}
+	return ready
+}
+
+// flush - Qolgan barcha ramkalarni chegaradagi ramkani takrorlagan holda chiqarish
+func (s *deltaStream) flush() []FrameFeatures {
+	if s.dc == nil {
+		return nil
+	}
+
+	var ready []FrameFeatures
+	for s.next < s.start+len(s.history) {
+		ready = append(ready, s.emit(s.next))
+		s.next++
+	}
+	s.history = s.history[:0]
+	s.start = s.next
+	return ready
+}
+
+// emit - Absolyut t-ramka uchun deltalarni hisoblab, uni qaytarish
+func (s *deltaStream) emit(t int) FrameFeatures {
+	local := t - s.start
+	f := s.history[local]
+	f.Delta, f.DeltaDelta = s.dc.compute(s.history, local)
+	return f
+}
@@ -0,0 +1,9 @@
+package internal
+
+import "errors"
+
+// ErrGPUNotCompiled - Kutubxona `cuda` build tegisiz yig‘ilganda GPU so‘ralsa qaytariladi
+var ErrGPUNotCompiled = errors.New("GPU support not compiled in (rebuild with -tags cuda)")
+
+// ErrAudioTooShort - To‘ldirish o‘chirilgan holda audio bitta ramkadan ham qisqa bo‘lsa qaytariladi
+var ErrAudioTooShort = errors.New("audio is shorter than one frame")
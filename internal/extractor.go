@@ -0,0 +1,256 @@
+package internal
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// FrameExtractor - Uzun audio signalni bo‘laklab qayta ishlaydi va har bir ramka xususiyatlarini tayyor bo‘lishi
+// bilan callback ga uzatadi. Pre-emphasis holati, ramkalar ustma-ustligi, chetlarni to‘ldirish, sirpanuvchi CMVN
+// va deltalar bo‘laklar orasida saqlanadi, shuning uchun natija butun signalga Process qo‘llangani bilan bir xil.
+// Xotirada faqat oxirgi ramka uzunligidagi namunalar, CMVN va delta oynalari saqlanadi.
+type FrameExtractor struct {
+	proc      *Processor
+	resampler *streamResampler // Kirish tezligi SampleRate dan farq qilsa
+	emit      func(FrameFeatures) error
+	cmvn      *slidingCMVNStream // CMVNSliding bo‘lsa
+	deltas    *deltaStream
+	first     int       // Birinchi ramka boshlanishi (to‘ldirish hisobiga manfiy bo‘lishi mumkin)
+	left      int       // Chap chetdagi aks uchun kerakli namunalar soni
+	prev      float32   // Oldingi bo‘lakning oxirgi namunasi (pre-emphasis uchun)
+	buffer    []float32 // Hali kerak bo‘ladigan namunalar, buffer[0] - offset-namuna
+	offset    int
+	received  int   // Jami qabul qilingan (qayta namunalangan) namunalar
+	next      int   // Hisoblanadigan keyingi ramka indeksi
+	err       error // Birinchi xatolik; undan keyin barcha chaqiruvlar shu xatolikni qaytaradi
+	closed    bool
+}
+
+// NewFrameExtractor - sampleRate tezligidagi signal uchun oqimli ekstraktor yaratish.
+// Signal SampleRate dan farq qilsa, bo‘laklar ResampleQuality sifatida oqim bo‘yicha qayta namunalanadi.
+// Butun audioni talab qiladigan sozlamalar (utterance CMVN, top_db) qo‘llab-quvvatlanmaydi.
+// emit ga faqat MFCC va deltalar uzatiladi, qo‘shimcha xususiyatlar hisoblanmaydi.
+func (p *Processor) NewFrameExtractor(sampleRate int, emit func(FrameFeatures) error) (*FrameExtractor, error) {
+	if emit == nil {
+		return nil, errors.New("callback funksiyasi nil bo‘lmasligi kerak")
+	}
+	if p.config.CMVN == CMVNUtterance {
+		return nil, fmt.Errorf("%q CMVN butun audioni talab qiladi va oqimli ekstraktorda ishlatib bo‘lmaydi", p.config.CMVN)
+	}
+	if p.topDBActive() {
+		return nil, errors.New("top_db butun audio bo‘yicha maksimumga bog‘liq va oqimli ekstraktorda ishlatib bo‘lmaydi")
+	}
+
+	e := &FrameExtractor{proc: p, emit: emit, deltas: newDeltaStream(p.deltas)}
+	if p.config.CMVN == CMVNSliding {
+		e.cmvn = newSlidingCMVNStream(p.config.CMVNWindow, p.config.CMVNCenter, p.config.CMVNNormVars)
+	}
+	if sampleRate != p.config.SampleRate {
+		var err error
+		if e.resampler, err = newStreamResampler(sampleRate, p.config.SampleRate, p.config.ResampleQuality); err != nil {
+			return nil, err
+		}
+	}
+	_, e.first = p.frameLayout(0)
+	e.left = max(0, -e.first)
+	return e, nil
+}
+
+// Write - Navbatdagi namunalar bo‘lagini qo‘shish; to‘liq ramkalar darhol hisoblanib callback ga uzatiladi
+func (e *FrameExtractor) Write(samples []float32) error {
+	if e.err != nil {
+		return e.err
+	}
+	if e.closed {
+		return errors.New("ekstraktor yopilgan")
+	}
+	if e.resampler != nil {
+		samples = e.resampler.write(samples)
+	}
+	e.append(samples)
+
+	// Ramka to‘liq kelgan va chap chetdagi aks uchun namunalar yetarli bo‘lsa, u oxirgi uzunlikka bog‘liq emas
+	var frames [][]float32
+	if e.received > e.left {
+		for {
+			start := e.first + e.next*e.proc.config.HopLength
+			if start+e.proc.config.FrameLength > e.received {
+				break
+			}
+			frames = append(frames, e.frame(start, start+e.proc.config.FrameLength))
+			e.next++
+		}
+	}
+	if err := e.process(frames); err != nil {
+		return err
+	}
+	e.trim()
+	return nil
+}
+
+// Close - Signal oxirini bildirish: qolgan ramkalar (o‘ng chetdagi to‘ldirish bilan) va delta buferi chiqariladi
+func (e *FrameExtractor) Close() error {
+	if e.err != nil {
+		return e.err
+	}
+	if e.closed {
+		return nil
+	}
+	e.closed = true
+	if e.resampler != nil {
+		e.append(e.resampler.flush())
+	}
+	if e.received == 0 {
+		return e.fail(errors.New("audio kirishi bo‘sh"))
+	}
+
+	cfg := e.proc.config
+	numFrames, _ := e.proc.frameLayout(e.received)
+	if numFrames <= 0 {
+		return e.fail(fmt.Errorf("%w: %d namuna, ramka uzunligi %d", ErrAudioTooShort, e.received, cfg.FrameLength))
+	}
+	var frames [][]float32
+	for ; e.next < numFrames; e.next++ {
+		start := e.first + e.next*cfg.HopLength
+		end := start + cfg.FrameLength
+		if cfg.Padding == PaddingTail {
+			// Oxirgi to‘liq bo‘lmagan ramka computeChunk da nollar bilan to‘ldiriladi
+			end = min(end, e.received)
+		}
+		frames = append(frames, e.frame(start, end))
+	}
+	if err := e.process(frames); err != nil {
+		return err
+	}
+	if e.cmvn != nil {
+		for _, f := range e.cmvn.flush() {
+			if err := e.push(f); err != nil {
+				return err
+			}
+		}
+	}
+	for _, f := range e.deltas.flush() {
+		if err := e.emit(f); err != nil {
+			return e.fail(err)
+		}
+	}
+	e.buffer = nil
+	return nil
+}
+
+// append - Namunalarni shkalalab, pre-emphasis ni oldingi bo‘lak holati bilan qo‘llab buferga qo‘shish
+func (e *FrameExtractor) append(samples []float32) {
+	if len(samples) == 0 {
+		return
+	}
+	cfg := e.proc.config
+	samples = e.proc.scaleSamples(samples)
+	if cfg.FramePreEmphasis || cfg.PreEmphasis == 0 {
+		e.buffer = append(e.buffer, samples...)
+	} else {
+		coeff := cfg.PreEmphasis
+		prev := e.prev
+		for i, v := range samples {
+			if e.received == 0 && i == 0 {
+				e.buffer = append(e.buffer, v)
+			} else {
+				e.buffer = append(e.buffer, v-coeff*prev)
+			}
+			prev = v
+		}
+	}
+	e.prev = samples[len(samples)-1]
+	e.received += len(samples)
+}
+
+// frame - [start, end) oralig‘idagi ramka; signal chegarasidan tashqaridagi namunalar edgePadMode bo‘yicha olinadi.
+// Chegara ichidagi ramka bufer ko‘rinishi sifatida (nusxasiz) qaytariladi.
+func (e *FrameExtractor) frame(start, end int) []float32 {
+	if start >= 0 && end <= e.received {
+		return e.buffer[start-e.offset : end-e.offset]
+	}
+	pad, _ := e.proc.edgePadMode()
+	index := reflectIndex
+	switch pad {
+	case PadSymmetric:
+		index = symmetricIndex
+	case PadEdge:
+		index = edgeIndex
+	}
+
+	frame := make([]float32, end-start)
+	for i := range frame {
+		j := start + i
+		if j < 0 || j >= e.received {
+			if pad == PadConstant {
+				continue
+			}
+			j = index(j, e.received)
+		}
+		frame[i] = e.buffer[j-e.offset]
+	}
+	return frame
+}
+
+// process - Ramkalarni hisoblab, CMVN va deltalardan keyin callback ga uzatish
+func (e *FrameExtractor) process(frames [][]float32) error {
+	if len(frames) == 0 {
+		return nil
+	}
+	p := e.proc
+	features := make([]FrameFeatures, len(frames))
+	err := p.forEachChunk(context.Background(), len(frames), func(start, end int) error {
+		return p.processChunk(frames[start:end], features[start:end], FeatureMFCC, nil, nil, nil)
+	})
+	if err != nil {
+		return e.fail(fmt.Errorf("xususiyatlarni hisoblashda xatolik: %w", err))
+	}
+	if e.cmvn == nil {
+		// Global CMVN har bir ramkaga alohida qo‘llanadi (utterance CMVN konstruktorda rad etilgan)
+		if err := p.applyCMVN(features); err != nil {
+			return e.fail(err)
+		}
+	}
+	for _, f := range features {
+		if e.cmvn == nil {
+			if err := e.push(f); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, ready := range e.cmvn.push(f) {
+			if err := e.push(ready); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// push - Normallashtirilgan ramkani delta buferiga qo‘shib, tayyor ramkalarni callback ga uzatish
+func (e *FrameExtractor) push(f FrameFeatures) error {
+	for _, ready := range e.deltas.push(f) {
+		if err := e.emit(ready); err != nil {
+			return e.fail(err)
+		}
+	}
+	return nil
+}
+
+// trim - Keyingi ramkalar va o‘ng chetdagi aks uchun kerak bo‘lmaydigan namunalarni tashlash.
+// Chap chetdagi aks uchun signal boshi birinchi ramkalar hisoblanguncha saqlanadi.
+func (e *FrameExtractor) trim() {
+	cfg := e.proc.config
+	keep := min(e.first+e.next*cfg.HopLength, e.received-cfg.FrameLength-1)
+	if drop := keep - e.offset; keep > 0 && drop > 0 {
+		e.buffer = append(e.buffer[:0], e.buffer[drop:]...)
+		e.offset = keep
+	}
+}
+
+// fail - Xatolikni saqlab, uni qaytarish
+func (e *FrameExtractor) fail(err error) error {
+	e.err = err
+	return err
+}
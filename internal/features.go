@@ -0,0 +1,44 @@
+package internal
+
+import "fmt"
+
+// FeatureSet - Ramka bo‘yicha hisoblanadigan xususiyatlar to‘plami (bit maskasi).
+// So‘ralmagan xususiyatlar hisoblanmaydi va FrameFeatures dagi mos maydonlar nol bo‘lib qoladi.
+type FeatureSet uint32
+
+const (
+	FeatureMFCC             FeatureSet = 1 << iota // MFCC (va DeltaOrder bo‘yicha deltalar)
+	FeatureZCR                                     // Zero-Crossing Rate
+	FeaturePitch                                   // Pitch, Voiced va VoicedProb
+	FeatureSpectralCentroid                        // Spectral Centroid
+	FeatureSpectralRollOff                         // Spectral Roll-off
+	FeatureEnergy                                  // Ramka energiyasi (RMS)
+
+	FeatureAll = FeatureMFCC | FeatureZCR | FeaturePitch | FeatureSpectralCentroid | FeatureSpectralRollOff | FeatureEnergy
+)
+
+// Has - To‘plamda f dagi barcha xususiyatlar borligini tekshirish
+func (s FeatureSet) Has(f FeatureSet) bool {
+	return s&f == f
+}
+
+// spectral - Backend natijasi (power spectrum yoki MFCC) kerak bo‘ladigan xususiyatlar so‘ralganligi
+func (s FeatureSet) spectral() bool {
+	return s&(FeatureMFCC|FeatureSpectralCentroid|FeatureSpectralRollOff) != 0
+}
+
+// featureSet - Konfiguratsiyadagi xususiyatlar to‘plami (0 bo‘lsa barcha xususiyatlar)
+func (c Config) featureSet() FeatureSet {
+	if c.Features == 0 {
+		return FeatureAll
+	}
+	return c.Features
+}
+
+// validateFeatures - Xususiyatlar maskasida noma’lum bitlar yo‘qligini tekshirish
+func validateFeatures(s FeatureSet) error {
+	if rest := s &^ FeatureAll; rest != 0 {
+		return fmt.Errorf("unknown feature flags %#x", uint32(rest))
+	}
+	return nil
+}
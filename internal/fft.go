@@ -0,0 +1,206 @@
+package internal
+
+import "math"
+
+// RealFFT - n uzunlikdagi haqiqiy signal uchun oldindan hisoblangan float32 FFT rejasi.
+// Juft n da signal n/2 uzunlikdagi kompleks signal sifatida o‘zgartiriladi (x[2k] + i·x[2k+1]) va
+// natija ajratish twiddle’lari bilan tiklanadi; toq n da to‘liq kompleks FFT ishlatiladi.
+// Kompleks FFT aralash radix (4, 2 va ixtiyoriy tub ko‘paytuvchilar) bilan ishlaydi, shuning uchun
+// 400 (25 ms @ 16 kHz) kabi ikki darajasi bo‘lmagan uzunliklar ham tez hisoblanadi.
+// Reja o‘zgarmas va goroutine’lar orasida bo‘lishilishi mumkin; vaqtinchalik bufer esa har bir goroutine’ga alohida.
+type RealFFT struct {
+	n     int
+	cfft  *complexFFT // n/2 (juft n) yoki n (toq n) uzunlikdagi kompleks FFT
+	split []complex64 // Juft n uchun ajratish twiddle’lari: exp(-iπ((k+1)/(n/2) + 1/2))
+}
+
+// complexFFT - Aralash radixli kompleks FFT rejasi (KISS FFT uslubidagi rekursiv decimation-in-time)
+type complexFFT struct {
+	n        int
+	factors  []int       // [p0, m0, p1, m1, ...]: radix va undan keyin qolgan uzunlik
+	twiddles []complex64 // exp(-2πik/n), k < n
+	maxRadix int
+}
+
+// NewRealFFT - n > 0 uzunlikdagi haqiqiy signal uchun FFT rejasini yaratish
+func NewRealFFT(n int) *RealFFT {
+	if n%2 == 1 {
+		return &RealFFT{n: n, cfft: newComplexFFT(n)}
+	}
+	half := n / 2
+	split := make([]complex64, half/2)
+	for k := range split {
+		phase := -math.Pi * (float64(k+1)/float64(half) + 0.5)
+		split[k] = complex(float32(math.Cos(phase)), float32(math.Sin(phase)))
+	}
+	return &RealFFT{n: n, cfft: newComplexFFT(half), split: split}
+}
+
+// Len - Reja mo‘ljallangan signal uzunligi
+func (f *RealFFT) Len() int {
+	return f.n
+}
+
+// BufferLen - PowerSpectrum uchun kerakli vaqtinchalik bufer uzunligi
+func (f *RealFFT) BufferLen() int {
+	return 2*f.cfft.n + f.cfft.maxRadix
+}
+
+// PowerSpectrum - |X[k]|², k = 0..n/2 qiymatlarini out ga yozish (len(out) = n/2+1).
+// frame uzunligi Len() ga, buf uzunligi kamida BufferLen() ga teng bo‘lishi kerak.
+func (f *RealFFT) PowerSpectrum(frame, out []float32, buf []complex64) {
+	m := f.cfft.n
+	in, spec, scratch := buf[:m], buf[m:2*m], buf[2*m:]
+
+	if f.n%2 == 1 {
+		for i, v := range frame {
+			in[i] = complex(v, 0)
+		}
+		f.cfft.transform(in, spec, scratch)
+		for k := range out {
+			out[k] = power(spec[k])
+		}
+		return
+	}
+
+	for k := range in {
+		in[k] = complex(frame[2*k], frame[2*k+1])
+	}
+	f.cfft.transform(in, spec, scratch)
+
+	// Juft va toq namunalar spektrlarini ajratib, haqiqiy signal spektrini tiklash
+	dc := spec[0]
+	out[0] = square(real(dc) + imag(dc))
+	out[m] = square(real(dc) - imag(dc))
+	for k := 1; k <= m/2; k++ {
+		fpk := spec[k]
+		fpnk := complex(real(spec[m-k]), -imag(spec[m-k]))
+		f1 := fpk + fpnk
+		tw := (fpk - fpnk) * f.split[k-1]
+		out[k] = power(0.5 * (f1 + tw))
+		out[m-k] = power(0.5 * (f1 - tw))
+	}
+}
+
+// newComplexFFT - n uzunlikdagi kompleks FFT rejasini yaratish
+func newComplexFFT(n int) *complexFFT {
+	f := &complexFFT{n: n, twiddles: make([]complex64, n), maxRadix: 1}
+	for k := range f.twiddles {
+		phase := -2 * math.Pi * float64(k) / float64(n)
+		f.twiddles[k] = complex(float32(math.Cos(phase)), float32(math.Sin(phase)))
+	}
+
+	// Avval 4, keyin 2, keyin toq tub ko‘paytuvchilar
+	p, rest := 4, n
+	limit := int(math.Sqrt(float64(n)))
+	for rest > 1 {
+		for rest%p != 0 {
+			switch p {
+			case 4:
+				p = 2
+			case 2:
+				p = 3
+			default:
+				p += 2
+			}
+			if p > limit {
+				p = rest
+			}
+		}
+		rest /= p
+		f.factors = append(f.factors, p, rest)
+		f.maxRadix = max(f.maxRadix, p)
+	}
+	if n == 1 {
+		f.factors = []int{1, 1}
+	}
+	return f
+}
+
+// transform - in ning FFT sini out ga yozish (in o‘zgarmaydi); scratch - kamida maxRadix uzunlikda
+func (f *complexFFT) transform(in, out, scratch []complex64) {
+	f.work(out, in, 1, f.factors, scratch)
+}
+
+// work - Rekursiv bosqich: out[i*m:(i+1)*m] ga stride*p qadamli kichik FFT’lar, keyin p-radix butterfly
+func (f *complexFFT) work(out, in []complex64, stride int, factors []int, scratch []complex64) {
+	p, m := factors[0], factors[1]
+	if m == 1 {
+		for i := 0; i < p; i++ {
+			out[i] = in[i*stride]
+		}
+	} else {
+		for i := 0; i < p; i++ {
+			f.work(out[i*m:(i+1)*m], in[i*stride:], stride*p, factors[2:], scratch)
+		}
+	}
+
+	switch p {
+	case 1:
+	case 2:
+		f.butterfly2(out, stride, m)
+	case 4:
+		f.butterfly4(out, stride, m)
+	default:
+		f.butterflyGeneric(out, stride, m, p, scratch)
+	}
+}
+
+// butterfly2 - Radix-2 butterfly
+func (f *complexFFT) butterfly2(out []complex64, stride, m int) {
+	for k := 0; k < m; k++ {
+		t := out[m+k] * f.twiddles[k*stride]
+		out[m+k] = out[k] - t
+		out[k] += t
+	}
+}
+
+// butterfly4 - Radix-4 butterfly (oldinga yo‘nalish, -i ga ko‘paytirish almashtirish bilan)
+func (f *complexFFT) butterfly4(out []complex64, stride, m int) {
+	tw := f.twiddles
+	for k := 0; k < m; k++ {
+		s0 := out[k+m] * tw[k*stride]
+		s1 := out[k+2*m] * tw[2*k*stride]
+		s2 := out[k+3*m] * tw[3*k*stride]
+		s5 := out[k] - s1
+		out[k] += s1
+		s3 := s0 + s2
+		s4 := s0 - s2
+		out[k+2*m] = out[k] - s3
+		out[k] += s3
+		out[k+m] = complex(real(s5)+imag(s4), imag(s5)-real(s4))
+		out[k+3*m] = complex(real(s5)-imag(s4), imag(s5)+real(s4))
+	}
+}
+
+// butterflyGeneric - Ixtiyoriy p radix uchun butterfly (to‘g‘ridan-to‘g‘ri p nuqtali DFT)
+func (f *complexFFT) butterflyGeneric(out []complex64, stride, m, p int, scratch []complex64) {
+	for u := 0; u < m; u++ {
+		for q, k := 0, u; q < p; q, k = q+1, k+m {
+			scratch[q] = out[k]
+		}
+		for q1, k := 0, u; q1 < p; q1, k = q1+1, k+m {
+			idx := 0
+			acc := scratch[0]
+			for q := 1; q < p; q++ {
+				// stride*k < n, shuning uchun bitta ayirish yetarli
+				idx += stride * k
+				if idx >= f.n {
+					idx -= f.n
+				}
+				acc += scratch[q] * f.twiddles[idx]
+			}
+			out[k] = acc
+		}
+	}
+}
+
+// power - Kompleks sonning kvadrat moduli
+func power(c complex64) float32 {
+	return real(c)*real(c) + imag(c)*imag(c)
+}
+
+// square - Kvadrat
+func square(x float32) float32 {
+	return x * x
+}
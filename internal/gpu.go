@@ -1,24 +1,85 @@
+//go:build cuda
+
 package internal
 
+// kernels.o repozitoriyga kiritilmaydi va kernels.cu dan yig‘iladi: go generate -tags cuda ./...
+//go:generate nvcc -c kernels.cu -o ../kernels.o
+
 /*
 #cgo CFLAGS: -I/usr/local/cuda-12.8/targets/x86_64-linux/include -Wall
-#cgo LDFLAGS: -L/usr/local/cuda-12.8/targets/x86_64-linux/lib -lcudart -lcufft /home/baxtiyor/go-mfcc/kernels.o
+#cgo LDFLAGS: -L/usr/local/cuda-12.8/targets/x86_64-linux/lib -lcudart -lcufft ${SRCDIR}/../kernels.o
 #include <cuda_runtime.h>
 #include <cufft.h>
 
-// Yordamchi funksiyalar deklaratsiyasi
+// Yordamchi funksiyalar deklaratsiyasi (imzo o‘zgarsa kernels.cu dagi ABI versiyasi bilan birga yangilanadi)
+int mfccKernelsABI3(void);
 void launchPowerSpectrumKernel(cufftComplex* fftOut, float* powerSpec, int n, int gridSize, int blockSize, cudaStream_t stream);
-void launchApplyMelFiltersKernel(float* powerSpec, float* filterBanks, float* melEnergies, int numFilters, int frameSize, int gridSize, int blockSize, cudaStream_t stream);
-void launchLogKernel(float* input, float* output, int n, int gridSize, int blockSize, cudaStream_t stream);
-void launchDctKernel(float* input, float* output, int n, int numCoeffs, float sqrt2OverN, int gridSize, int blockSize, cudaStream_t stream);
+void launchApplyMelFiltersKernel(float* powerSpec, int* filterStarts, int* filterOffsets, float* filterWeights, float* melEnergies, int numFilters, int gridSize, int blockSize, cudaStream_t stream);
+void launchLogKernel(float* input, float* output, int n, int logMode, int gridSize, int blockSize, cudaStream_t stream);
+void launchDctKernel(float* input, float* output, float* dctBasis, float* dctScale, int n, int numCoeffs, float cepLifter, int gridSize, int blockSize, cudaStream_t stream);
 */
 import "C"
 import (
 	"fmt"
-	"math"
+	"sync"
 	"unsafe"
 )
 
+func init() {
+	// kernels.o joriy kernels.cu dan yig‘ilganligini bog‘lash bosqichida tekshirish
+	_ = C.mfccKernelsABI3()
+	mustRegisterBackend(BackendCUDA, newCUDABackend)
+}
+
+// cudaBackend - GPUContext ustidagi Backend amalga oshirishi.
+// GPU buferlari umumiy bo‘lgani uchun hisoblashlar mutex bilan ketma-ket bajariladi.
+type cudaBackend struct {
+	ctx *GPUContext
+	mu  sync.Mutex
+}
+
+// newCUDABackend - Yangi CUDA backend yaratish
+func newCUDABackend(spec BackendSpec) (Backend, error) {
+	cfg := spec.Config
+	ctx, err := NewGPUContext(cfg.fftLength(), cfg.NumFilters, cfg.NumCoefficients)
+	if err != nil {
+		return nil, err
+	}
+	ctx.cepLifter = float32(cfg.CepLifter)
+	switch cfg.LogMode {
+	case LogDB:
+		ctx.logMode = 1
+	case LogKaldi:
+		ctx.logMode = 2
+	}
+	if err := ctx.UploadMelFilters(spec.melFilters()); err != nil {
+		ctx.Cleanup()
+		return nil, err
+	}
+	if err := ctx.UploadDCT(spec.dctMatrix()); err != nil {
+		ctx.Cleanup()
+		return nil, err
+	}
+	return &cudaBackend{ctx: ctx}, nil
+}
+
+// Name - Backend nomini qaytaradi
+func (b *cudaBackend) Name() string {
+	return BackendCUDA
+}
+
+// Compute - Ramkalar to‘plamini GPU’da qayta ishlaydi
+func (b *cudaBackend) Compute(frames [][]float32) (*BackendResult, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.ctx.ComputeBatch(frames)
+}
+
+// Close - GPU resurslarini ozod qilish
+func (b *cudaBackend) Close() error {
+	return b.ctx.Cleanup()
+}
+
 // GPUContext - GPU’da hisoblash uchun kontekst
 type GPUContext struct {
 	plan          C.cufftHandle
@@ -29,11 +90,17 @@ type GPUContext struct {
 	deviceMel     unsafe.Pointer
 	deviceLog     unsafe.Pointer
 	deviceDCT     unsafe.Pointer
-	deviceFilters unsafe.Pointer
+	filterStarts  unsafe.Pointer // Har bir siyrak filtrning birinchi bin’i (int)
+	filterOffsets unsafe.Pointer // Filtr og‘irliklarining filterWeights dagi boshlanishi, numFilters+1 ta (int)
+	filterWeights unsafe.Pointer // Barcha filtrlarning nolga teng bo‘lmagan og‘irliklari ketma-ket
+	dctBasis      unsafe.Pointer // DCTMatrix.Basis
+	dctScale      unsafe.Pointer // DCTMatrix.Scale
 	hostBuffer    []float32
 	frameLength   int
 	numFilters    int
 	numCoeffs     int
+	cepLifter     float32 // Kepstral lifter parametri (0 - o‘chirilgan)
+	logMode       int     // Log turi: 0 - natural, 1 - dB, 2 - Kaldi (FLT_EPSILON chegarasi)
 }
 
 // NewGPUContext - Yangi GPU kontekstini yaratish
@@ -71,29 +138,71 @@ func NewGPUContext(frameLength, numFilters, numCoefficients int) (*GPUContext, e
 	if res := C.cudaMalloc(&ctx.deviceDCT, C.size_t(numCoefficients*4)); res != C.cudaSuccess {
 		return nil, fmt.Errorf("deviceDCT uchun xotira ajratishda xatolik: %v", res)
 	}
-	if res := C.cudaMalloc(&ctx.deviceFilters, C.size_t(numFilters*(frameLength/2+1)*4)); res != C.cudaSuccess {
-		return nil, fmt.Errorf("deviceFilters uchun xotira ajratishda xatolik: %v", res)
-	}
 
 	ctx.hostBuffer = make([]float32, frameLength)
 	return &ctx, nil
 }
 
-// ComputeMFCC - GPU’da MFCC ni hisoblash
-func (ctx *GPUContext) ComputeMFCC(frames [][]float32, filterBanks [][]float32, window []float32, cfg Config) ([][]float32, error) {
-	numFrames := len(frames)
-	mfccs := make([][]float32, numFrames)
+// UploadMelFilters - Siyrak Mel filtrlar bankini GPU xotirasiga ko‘chirish.
+// Kernel har bir filtr uchun faqat nolga teng bo‘lmagan bin’larni yig‘adi (CPU backend bilan bir xil ko‘rinish).
+func (ctx *GPUContext) UploadMelFilters(filters MelFilterBank) error {
+	starts := make([]C.int, len(filters))
+	offsets := make([]C.int, len(filters)+1)
+	var weights []float32
+	for i, filter := range filters {
+		starts[i] = C.int(filter.Start)
+		offsets[i] = C.int(len(weights))
+		weights = append(weights, filter.Weights...)
+	}
+	offsets[len(filters)] = C.int(len(weights))
+	if len(weights) == 0 {
+		// Bo‘sh bufer uchun ham xotira ajratiladi, kernel undan o‘qimaydi
+		weights = make([]float32, 1)
+	}
+
+	if err := ctx.upload(&ctx.filterStarts, unsafe.Pointer(&starts[0]), len(starts)*4, "filtrlar boshlanishi"); err != nil {
+		return err
+	}
+	if err := ctx.upload(&ctx.filterOffsets, unsafe.Pointer(&offsets[0]), len(offsets)*4, "filtrlar siljishlari"); err != nil {
+		return err
+	}
+	return ctx.upload(&ctx.filterWeights, unsafe.Pointer(&weights[0]), len(weights)*4, "filtrlar og‘irliklari")
+}
 
-	flatFilters := make([]float32, ctx.numFilters*(ctx.frameLength/2+1))
-	for i, filter := range filterBanks {
-		copy(flatFilters[i*(ctx.frameLength/2+1):], filter)
+// UploadDCT - Oldindan hisoblangan DCT bazisini GPU xotirasiga ko‘chirish
+func (ctx *GPUContext) UploadDCT(dct *DCTMatrix) error {
+	if dct.NumFilters != ctx.numFilters || dct.NumCoefficients != ctx.numCoeffs {
+		return fmt.Errorf("DCT bazisi o‘lchami (%d×%d) kontekstga mos kelmadi", dct.NumCoefficients, dct.NumFilters)
+	}
+	if err := ctx.upload(&ctx.dctBasis, unsafe.Pointer(&dct.Basis[0]), len(dct.Basis)*4, "DCT bazisi"); err != nil {
+		return err
+	}
+	return ctx.upload(&ctx.dctScale, unsafe.Pointer(&dct.Scale[0]), len(dct.Scale)*4, "DCT ko‘paytuvchilari")
+}
+
+// upload - GPU’da size baytli bufer ajratib, src dan ko‘chirish
+func (ctx *GPUContext) upload(dst *unsafe.Pointer, src unsafe.Pointer, size int, name string) error {
+	if res := C.cudaMalloc(dst, C.size_t(size)); res != C.cudaSuccess {
+		return fmt.Errorf("%s uchun xotira ajratishda xatolik: %v", name, res)
+	}
+	if res := C.cudaMemcpy(*dst, src, C.size_t(size), C.cudaMemcpyHostToDevice); res != C.cudaSuccess {
+		return fmt.Errorf("%s ni GPU’ga ko‘chirishda xatolik: %v", name, res)
 	}
-	if res := C.cudaMemcpy(ctx.deviceFilters, unsafe.Pointer(&flatFilters[0]), C.size_t(len(flatFilters)*4), C.cudaMemcpyHostToDevice); res != C.cudaSuccess {
-		return nil, fmt.Errorf("filtrlar bankini GPU’ga ko‘chirishda xatolik: %v", res)
+	return nil
+}
+
+// ComputeBatch - GPU’da oyna qo‘llangan ramkalar uchun power spectrum, Mel energiyalari va MFCC ni hisoblash
+func (ctx *GPUContext) ComputeBatch(frames [][]float32) (*BackendResult, error) {
+	numFrames := len(frames)
+	res := &BackendResult{
+		PowerSpectra: make([][]float32, numFrames),
+		MelEnergies:  make([][]float32, numFrames),
+		MFCC:         make([][]float32, numFrames),
 	}
+	fftSize := ctx.frameLength/2 + 1
 
 	blockSize := C.int(256)
-	powerGridSize := C.int((ctx.frameLength/2 + 1 + int(blockSize) - 1) / int(blockSize))
+	powerGridSize := C.int((fftSize + int(blockSize) - 1) / int(blockSize))
 	melGridSize := C.int((ctx.numFilters + int(blockSize) - 1) / int(blockSize))
 	dctGridSize := C.int((ctx.numCoeffs + int(blockSize) - 1) / int(blockSize))
 
@@ -102,10 +211,7 @@ func (ctx *GPUContext) ComputeMFCC(frames [][]float32, filterBanks [][]float32,
 			return nil, fmt.Errorf("ramka uzunligi mos kelmadi")
 		}
 
-		for j := range frame {
-			ctx.hostBuffer[j] = frame[j] * window[j]
-		}
-
+		copy(ctx.hostBuffer, frame)
 		if res := C.cudaMemcpy(ctx.deviceFrame, unsafe.Pointer(&ctx.hostBuffer[0]), C.size_t(ctx.frameLength*4), C.cudaMemcpyHostToDevice); res != C.cudaSuccess {
 			return nil, fmt.Errorf("ma’lumotni GPU’ga ko‘chirishda xatolik: %v", res)
 		}
@@ -126,10 +232,11 @@ func (ctx *GPUContext) ComputeMFCC(frames [][]float32, filterBanks [][]float32,
 
 		C.launchApplyMelFiltersKernel(
 			(*C.float)(ctx.devicePower),
-			(*C.float)(ctx.deviceFilters),
+			(*C.int)(ctx.filterStarts),
+			(*C.int)(ctx.filterOffsets),
+			(*C.float)(ctx.filterWeights),
 			(*C.float)(ctx.deviceMel),
 			C.int(ctx.numFilters),
-			C.int(ctx.frameLength),
 			melGridSize,
 			blockSize,
 			ctx.stream,
@@ -140,33 +247,45 @@ func (ctx *GPUContext) ComputeMFCC(frames [][]float32, filterBanks [][]float32,
 			(*C.float)(ctx.deviceMel),
 			(*C.float)(ctx.deviceLog),
 			C.int(ctx.numFilters),
+			C.int(ctx.logMode),
 			melGridSize,
 			blockSize,
 			ctx.stream,
 		)
 		C.cudaStreamSynchronize(ctx.stream)
 
-		sqrt2OverN := float32(math.Sqrt(2.0 / float64(ctx.numFilters)))
 		C.launchDctKernel(
 			(*C.float)(ctx.deviceLog),
 			(*C.float)(ctx.deviceDCT),
+			(*C.float)(ctx.dctBasis),
+			(*C.float)(ctx.dctScale),
 			C.int(ctx.numFilters),
 			C.int(ctx.numCoeffs),
-			C.float(sqrt2OverN),
+			C.float(ctx.cepLifter),
 			dctGridSize,
 			blockSize,
 			ctx.stream,
 		)
 		C.cudaStreamSynchronize(ctx.stream)
 
+		powerResult := make([]float32, fftSize)
+		if res := C.cudaMemcpy(unsafe.Pointer(&powerResult[0]), ctx.devicePower, C.size_t(fftSize*4), C.cudaMemcpyDeviceToHost); res != C.cudaSuccess {
+			return nil, fmt.Errorf("power spectrumni GPU’dan olishda xatolik: %v", res)
+		}
+		melResult := make([]float32, ctx.numFilters)
+		if res := C.cudaMemcpy(unsafe.Pointer(&melResult[0]), ctx.deviceMel, C.size_t(ctx.numFilters*4), C.cudaMemcpyDeviceToHost); res != C.cudaSuccess {
+			return nil, fmt.Errorf("Mel energiyalarini GPU’dan olishda xatolik: %v", res)
+		}
 		dctResult := make([]float32, ctx.numCoeffs)
 		if res := C.cudaMemcpy(unsafe.Pointer(&dctResult[0]), ctx.deviceDCT, C.size_t(ctx.numCoeffs*4), C.cudaMemcpyDeviceToHost); res != C.cudaSuccess {
 			return nil, fmt.Errorf("DCT natijasini GPU’dan olishda xatolik: %v", res)
 		}
-		mfccs[i] = dctResult
+		res.PowerSpectra[i] = powerResult
+		res.MelEnergies[i] = melResult
+		res.MFCC[i] = dctResult
 	}
 
-	return mfccs, nil
+	return res, nil
 }
 
 // Cleanup - GPU resurslarini ozod qilish
@@ -195,8 +314,10 @@ func (ctx *GPUContext) Cleanup() error {
 	if ctx.deviceDCT != nil {
 		C.cudaFree(ctx.deviceDCT)
 	}
-	if ctx.deviceFilters != nil {
-		C.cudaFree(ctx.deviceFilters)
+	for _, ptr := range []unsafe.Pointer{ctx.filterStarts, ctx.filterOffsets, ctx.filterWeights, ctx.dctBasis, ctx.dctScale} {
+		if ptr != nil {
+			C.cudaFree(ptr)
+		}
 	}
 	return nil
 }
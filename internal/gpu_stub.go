@@ -0,0 +1,10 @@
+//go:build !cuda
+
+package internal
+
+func init() {
+	// "cuda" nomi band bo‘lib turadi, shunda UseGPU aniq xatolik bilan rad etiladi
+	mustRegisterBackend(BackendCUDA, func(BackendSpec) (Backend, error) {
+		return nil, ErrGPUNotCompiled
+	})
+}
@@ -11,52 +11,70 @@
                                                }
                                            }
 
-                                           // Mel filtrlarini qo‘llash uchun CUDA kernel
-                                           __global__ void applyMelFiltersKernel(float* powerSpec, float* filterBanks, float* melEnergies, int numFilters, int frameSize) {
+                                           // Siyrak Mel filtrlarini qo‘llash uchun CUDA kernel: faqat nolga teng bo‘lmagan bin’lar yig‘iladi
+                                           __global__ void applyMelFiltersKernel(float* powerSpec, int* filterStarts, int* filterOffsets, float* filterWeights, float* melEnergies, int numFilters) {
                                                int idx = blockIdx.x * blockDim.x + threadIdx.x;
                                                if (idx < numFilters) {
                                                    float energy = 0.0f;
-                                                   for (int j = 0; j < frameSize/2 + 1; j++) {
-                                                       energy += powerSpec[j] * filterBanks[idx * (frameSize/2 + 1) + j];
+                                                   int start = filterStarts[idx];
+                                                   for (int j = filterOffsets[idx]; j < filterOffsets[idx + 1]; j++) {
+                                                       energy += filterWeights[j] * powerSpec[start + j - filterOffsets[idx]];
                                                    }
                                                    melEnergies[idx] = energy;
                                                }
                                            }
 
                                            // Log operatsiyasi uchun CUDA kernel
-                                           __global__ void logKernel(float* input, float* output, int n) {
+                                           __global__ void logKernel(float* input, float* output, int n, int logMode) {
                                                int idx = blockIdx.x * blockDim.x + threadIdx.x;
                                                if (idx < n) {
-                                                   output[idx] = logf(input[idx] + 1e-6f);
+                                                   if (logMode == 1) {
+                                                       // librosa power_to_db: 10 * log10(max(x, amin)), amin = 1e-10
+                                                       output[idx] = 10.0f * log10f(fmaxf(input[idx], 1e-10f));
+                                                   } else if (logMode == 2) {
+                                                       // Kaldi: ln(max(x, FLT_EPSILON))
+                                                       output[idx] = logf(fmaxf(input[idx], 1.1920929e-07f));
+                                                   } else {
+                                                       output[idx] = logf(input[idx] + 1e-6f);
+                                                   }
                                                }
                                            }
 
-                                           // DCT uchun CUDA kernel
-                                           __global__ void dctKernel(float* input, float* output, int n, int numCoeffs, float sqrt2OverN) {
+                                           // DCT uchun CUDA kernel (oldindan hisoblangan bazis, CPU backend bilan bir xil DCTMatrix)
+                                           __global__ void dctKernel(float* input, float* output, float* dctBasis, float* dctScale, int n, int numCoeffs, float cepLifter) {
                                                int idx = blockIdx.x * blockDim.x + threadIdx.x;
                                                if (idx < numCoeffs) {
                                                    float sum = 0.0f;
                                                    for (int m = 0; m < n; m++) {
-                                                       float angle = 3.14159265359 * idx * (m + 0.5) / n;
-                                                       sum += input[m] * cosf(angle);
+                                                       sum += input[m] * dctBasis[idx * n + m];
+                                                   }
+                                                   output[idx] = sum * dctScale[idx];
+                                                   // Sinusoidal lifter (HTK/Kaldi): 1 + (L/2) * sin(pi * n / L)
+                                                   if (cepLifter > 0.0f) {
+                                                       output[idx] *= 1.0f + 0.5f * cepLifter * sinpif(idx / cepLifter);
                                                    }
-                                                   output[idx] = sum * sqrt2OverN;
                                                }
                                            }
 
+                                           // Launcher imzolari versiyasi. Quyidagi extern "C" funksiyalar imzosi o‘zgarganda nom gpu.go bilan birga
+                                           // oshiriladi: eski kernels.o da bu belgi bo‘lmagani uchun `go build -tags cuda` bog‘lash bosqichida to‘xtaydi.
+                                           extern "C" int mfccKernelsABI3(void) {
+                                               return 3;
+                                           }
+
                                            // CUDA kernelni Go’dan chaqirish uchun yordamchi funksiyalar
                                            extern "C" void launchPowerSpectrumKernel(cufftComplex* fftOut, float* powerSpec, int n, int gridSize, int blockSize, cudaStream_t stream) {
                                                powerSpectrumKernel<<<gridSize, blockSize, 0, stream>>>(fftOut, powerSpec, n);
                                            }
 
-                                           extern "C" void launchApplyMelFiltersKernel(float* powerSpec, float* filterBanks, float* melEnergies, int numFilters, int frameSize, int gridSize, int blockSize, cudaStream_t stream) {
-                                               applyMelFiltersKernel<<<gridSize, blockSize, 0, stream>>>(powerSpec, filterBanks, melEnergies, numFilters, frameSize);
+                                           extern "C" void launchApplyMelFiltersKernel(float* powerSpec, int* filterStarts, int* filterOffsets, float* filterWeights, float* melEnergies, int numFilters, int gridSize, int blockSize, cudaStream_t stream) {
+                                               applyMelFiltersKernel<<<gridSize, blockSize, 0, stream>>>(powerSpec, filterStarts, filterOffsets, filterWeights, melEnergies, numFilters);
                                            }
 
-                                           extern "C" void launchLogKernel(float* input, float* output, int n, int gridSize, int blockSize, cudaStream_t stream) {
-                                               logKernel<<<gridSize, blockSize, 0, stream>>>(input, output, n);
+                                           extern "C" void launchLogKernel(float* input, float* output, int n, int logMode, int gridSize, int blockSize, cudaStream_t stream) {
+                                               logKernel<<<gridSize, blockSize, 0, stream>>>(input, output, n, logMode);
                                            }
 
-                                           extern "C" void launchDctKernel(float* input, float* output, int n, int numCoeffs, float sqrt2OverN, int gridSize, int blockSize, cudaStream_t stream) {
-                                               dctKernel<<<gridSize, blockSize, 0, stream>>>(input, output, n, numCoeffs, sqrt2OverN);
+                                           extern "C" void launchDctKernel(float* input, float* output, float* dctBasis, float* dctScale, int n, int numCoeffs, float cepLifter, int gridSize, int blockSize, cudaStream_t stream) {
+                                               dctKernel<<<gridSize, blockSize, 0, stream>>>(input, output, dctBasis, dctScale, n, numCoeffs, cepLifter);
                                            }
\ No newline at end of file
@@ -1,66 +1,198 @@
 package internal
 
-import "math"
+import (
+	"fmt"
+	"math"
+)
 
-// createMelFilterBanks - Mel filtrlar bankini yaratish
-func createMelFilterBanks(sampleRate, frameLength, numFilters int, lowFreq, highFreq float32) [][]float32 {
-	nyquist := float32(sampleRate) / 2.0
-	if highFreq == 0 {
-		highFreq = nyquist
+// MelScale - Hz va Mel shkalasi orasidagi o‘tkazish formulasi
+type MelScale string
+
+const (
+	MelScaleHTK    MelScale = "htk"    // HTK formulasi: 2595·log10(1 + f/700)
+	MelScaleSlaney MelScale = "slaney" // Slaney (Auditory Toolbox): 1 kHz gacha chiziqli, undan keyin logarifmik
+	MelScaleKaldi  MelScale = "kaldi"  // Kaldi: 1127·ln(1 + f/700), uchburchaklar Mel sohasida chiziqli
+)
+
+// MelNorm - Mel filtrlarini normallashtirish usuli
+type MelNorm string
+
+const (
+	MelNormNone   MelNorm = "none"   // Normalizatsiya yo‘q (uchburchak cho‘qqisi 1)
+	MelNormSlaney MelNorm = "slaney" // Har bir filtr yuzasini tenglashtirish (librosa `norm='slaney'`)
+	MelNormL1     MelNorm = "l1"     // Har bir filtr L1 normasi 1 ga teng
+	MelNormL2     MelNorm = "l2"     // Har bir filtr L2 normasi 1 ga teng
+)
+
+// Slaney shkalasi parametrlari (librosa bilan bir xil)
+const (
+	slaneyFSp       = 200.0 / 3 // Chiziqli qismdagi Hz/Mel nisbati
+	slaneyMinLogHz  = 1000.0    // Logarifmik qism boshlanadigan chastota
+	slaneyMinLogMel = slaneyMinLogHz / slaneyFSp
+)
+
+var slaneyLogStep = math.Log(6.4) / 27
+
+// validateMelOptions - Mel shkalasi va normalizatsiya turlarini tekshirish
+func validateMelOptions(scale MelScale, norm MelNorm) error {
+	switch scale {
+	case "", MelScaleHTK, MelScaleSlaney, MelScaleKaldi:
+	default:
+		return fmt.Errorf("unknown mel scale %q", scale)
 	}
-	lowMel := hzToMel(lowFreq)
-	highMel := hzToMel(highFreq)
-	melStep := (highMel - lowMel) / float32(numFilters+1)
-
-	// Mel nuqtalarini oldindan hisoblash
-	melPoints := make([]float32, numFilters+2)
-	for i := 0; i < len(melPoints); i++ {
-		melPoints[i] = lowMel + float32(i)*melStep
+	switch norm {
+	case "", MelNormNone, MelNormSlaney, MelNormL1, MelNormL2:
+	default:
+		return fmt.Errorf("unknown mel norm %q", norm)
 	}
-	hzPoints := make([]float32, len(melPoints))
+	return nil
+}
+
+// createMelFilterBanks - Mel filtrlar bankini yaratish.
+// Hisoblash librosa `filters.mel` bilan bir xil tartibda float64 da bajariladi va float32 ga o‘tkaziladi.
+// MelScaleKaldi tanlansa, uchburchaklar Kaldi `MelBanks` kabi Mel sohasida quriladi.
+func createMelFilterBanks(cfg Config) [][]float32 {
+	sampleRate := float64(cfg.SampleRate)
+	highFreq := float64(cfg.HighFreq)
+	if highFreq == 0 {
+		highFreq = sampleRate / 2
+	}
+
+	// Mel nuqtalarini oldindan hisoblash: Mel shkalasida teng oraliqli numFilters+2 nuqta
+	lowMel := hzToMel(float64(cfg.LowFreq), cfg.MelScale)
+	highMel := hzToMel(highFreq, cfg.MelScale)
+	melPoints := make([]float64, cfg.NumFilters+2)
+	hzPoints := make([]float64, cfg.NumFilters+2)
 	for i := range hzPoints {
-		hzPoints[i] = melToHz(melPoints[i])
+		melPoints[i] = lowMel + float64(i)*(highMel-lowMel)/float64(len(hzPoints)-1)
+		hzPoints[i] = melToHz(melPoints[i], cfg.MelScale)
 	}
 
-	fftSize := frameLength/2 + 1
-	filterBanks := make([][]float32, numFilters)
-	for i := 0; i < numFilters; i++ {
+	fftLength := cfg.fftLength()
+	fftSize := fftLength/2 + 1
+	filterBanks := make([][]float32, cfg.NumFilters)
+	for i := range filterBanks {
 		filterBanks[i] = make([]float32, fftSize)
 		for j := 0; j < fftSize; j++ {
-			freq := float32(j) * nyquist / float32(fftSize-1)
-			if freq >= hzPoints[i] && freq <= hzPoints[i+1] {
-				filterBanks[i][j] = (freq - hzPoints[i]) / (hzPoints[i+1] - hzPoints[i])
-			} else if freq > hzPoints[i+1] && freq <= hzPoints[i+2] {
-				filterBanks[i][j] = (hzPoints[i+2] - freq) / (hzPoints[i+2] - hzPoints[i+1])
+			freq := float64(j) * sampleRate / float64(fftLength)
+			if cfg.MelScale != MelScaleKaldi {
+				filterBanks[i][j] = float32(triangle(freq, hzPoints[i:i+3]))
+			} else if j < fftLength/2 {
+				// Kaldi Nyquist bin’ini filtrlarga kiritmaydi
+				filterBanks[i][j] = float32(triangle(hzToMel(freq, cfg.MelScale), melPoints[i:i+3]))
+			}
+		}
+		if cfg.MelNorm == MelNormSlaney {
+			// Har bir filtrni kengligiga bo‘lish, shunda filtrlar yuzasi taxminan teng bo‘ladi
+			enorm := 2 / (hzPoints[i+2] - hzPoints[i])
+			for j := range filterBanks[i] {
+				filterBanks[i][j] = float32(float64(filterBanks[i][j]) * enorm)
 			}
 		}
 	}
+
+	switch cfg.MelNorm {
+	case MelNormL1:
+		normalizeRows(filterBanks, 1)
+	case MelNormL2:
+		normalizeRows(filterBanks, 2)
+	}
 	return filterBanks
 }
 
-func hzToMel(hz float32) float32 {
-	return 2595 * float32(math.Log10(1+float64(hz)/700))
+// SparseFilter - Mel filtrining nolga teng bo‘lmagan oralig‘i: Weights[j] power spectrumning Start+j bin’iga ko‘paytiriladi
+type SparseFilter struct {
+	Start   int
+	Weights []float32
 }
 
-func melToHz(mel float32) float32 {
-	return 700 * (float32(math.Pow(10, float64(mel)/2595)) - 1)
+// MelFilterBank - Mel filtrlar bankining siyrak ko‘rinishi. Har bir uchburchak faqat bir necha bin’da nolga teng emas,
+// shuning uchun filtrlash FFT kengligidagi zich qatorlar o‘rniga faqat shu oraliqlar bo‘yicha bajariladi.
+type MelFilterBank []SparseFilter
+
+// NewMelFilterBank - Zich filtrlar bankidan siyrak ko‘rinish yaratish (har bir qator chetlaridagi nollar tashlanadi)
+func NewMelFilterBank(filterBanks [][]float32) MelFilterBank {
+	bank := make(MelFilterBank, len(filterBanks))
+	for i, filter := range filterBanks {
+		start, end := 0, len(filter)
+		for start < end && filter[start] == 0 {
+			start++
+		}
+		for end > start && filter[end-1] == 0 {
+			end--
+		}
+		bank[i] = SparseFilter{Start: start, Weights: append([]float32(nil), filter[start:end]...)}
+	}
+	return bank
 }
 
-func linSpace(start, end float32, num int) []float32 {
-	if num <= 0 {
-		return nil
+// Apply - Power spectrumga filtrlarni qo‘llab, energiyalarni out ga yozish (len(out) = len(bank)).
+// Yig‘ish tartibi zich filtr bilan bir xil, shuning uchun natija bit darajasida o‘zgarmaydi.
+func (bank MelFilterBank) Apply(powerSpectrum, out []float32) []float32 {
+	for i, filter := range bank {
+		weights := filter.Weights
+		if n := len(powerSpectrum) - filter.Start; n < len(weights) {
+			weights = weights[:max(n, 0)]
+		}
+		var energy float32
+		for j, w := range weights {
+			energy += w * powerSpectrum[filter.Start+j]
+		}
+		out[i] = energy
 	}
+	return out
+}
+
+// triangle - x nuqtadagi uchburchak filtr qiymati (points: chap chekka, cho‘qqi, o‘ng chekka)
+func triangle(x float64, points []float64) float64 {
+	lower := (x - points[0]) / (points[1] - points[0])
+	upper := (points[2] - x) / (points[2] - points[1])
+	return math.Max(0, math.Min(lower, upper))
+}
 
-	result := make([]float32, num)
-	if num == 1 {
-		result[0] = start
-		return result
+// normalizeRows - Har bir filtrni Lp normasiga bo‘lish (nol filtrlar o‘zgarmaydi)
+func normalizeRows(filterBanks [][]float32, p float64) {
+	for _, filter := range filterBanks {
+		var sum float64
+		for _, w := range filter {
+			sum += math.Pow(math.Abs(float64(w)), p)
+		}
+		norm := math.Pow(sum, 1/p)
+		if norm == 0 {
+			continue
+		}
+		for j := range filter {
+			filter[j] = float32(float64(filter[j]) / norm)
+		}
 	}
+}
 
-	step := (end - start) / float32(num-1)
-	for i := range result {
-		result[i] = start + float32(i)*step
+// hzToMel - Chastotani Mel shkalasiga o‘tkazish
+func hzToMel(hz float64, scale MelScale) float64 {
+	switch scale {
+	case MelScaleKaldi:
+		return 1127 * math.Log(1+hz/700)
+	case MelScaleSlaney:
+	default:
+		return 2595 * math.Log10(1+hz/700)
 	}
+	if hz >= slaneyMinLogHz {
+		return slaneyMinLogMel + math.Log(hz/slaneyMinLogHz)/slaneyLogStep
+	}
+	return hz / slaneyFSp
+}
 
-	return result
+// melToHz - Mel qiymatini chastotaga o‘tkazish
+func melToHz(mel float64, scale MelScale) float64 {
+	switch scale {
+	case MelScaleKaldi:
+		return 700 * (math.Exp(mel/1127) - 1)
+	case MelScaleSlaney:
+	default:
+		return 700 * (math.Pow(10, mel/2595) - 1)
+	}
+	if mel >= slaneyMinLogMel {
+		return slaneyMinLogHz * math.Exp(slaneyLogStep*(mel-slaneyMinLogMel))
+	}
+	return slaneyFSp * mel
 }
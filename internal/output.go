@@ -0,0 +1,45 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+)
+
+// OutputKind - ProcessOutput qaytaradigan spektral matritsa turi
+type OutputKind string
+
+const (
+	OutputLogMel            OutputKind = "log_mel"   // Log-Mel filtrlar banki energiyalari (fbank)
+	OutputMel               OutputKind = "mel"       // Chiziqli Mel energiyalari
+	OutputPowerSpectrum     OutputKind = "power"     // Power spektrogramma (|X|²)
+	OutputMagnitudeSpectrum OutputKind = "magnitude" // Magnituda spektrogrammasi (|X|)
+)
+
+// validate - Chiqish turi ma’lumligini tekshirish
+func (k OutputKind) validate() error {
+	switch k {
+	case OutputLogMel, OutputMel, OutputPowerSpectrum, OutputMagnitudeSpectrum:
+		return nil
+	default:
+		return fmt.Errorf("noma’lum chiqish turi %q", k)
+	}
+}
+
+// selectOutput - Backend natijasidan i-ramka uchun kerakli qatorni tanlash
+func selectOutput(res *BackendResult, i int, kind OutputKind, logMode LogMode) []float32 {
+	switch kind {
+	case OutputMel:
+		return res.MelEnergies[i]
+	case OutputLogMel:
+		return applyLogMode(res.MelEnergies[i], make([]float32, len(res.MelEnergies[i])), logMode)
+	case OutputMagnitudeSpectrum:
+		power := res.PowerSpectra[i]
+		magnitude := make([]float32, len(power))
+		for j, v := range power {
+			magnitude[j] = float32(math.Sqrt(float64(v)))
+		}
+		return magnitude
+	default:
+		return res.PowerSpectra[i]
+	}
+}
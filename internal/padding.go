@@ -0,0 +1,107 @@
+package internal
+
+import "fmt"
+
+// PaddingMode - Signal chegaralarini ramkalashdan oldin to‘ldirish usuli
+type PaddingMode string
+
+const (
+	PaddingNone   PaddingMode = "none"   // To‘ldirish yo‘q (snip), faqat to‘liq ramkalar olinadi
+	PaddingCenter PaddingMode = "center" // Signal ikki tomondan FrameLength/2 ga to‘ldiriladi (librosa `center=True`)
+	PaddingTail   PaddingMode = "tail"   // Oxirgi to‘liq bo‘lmagan ramka nollar bilan to‘ldiriladi, dum namunalari tashlanmaydi
+	PaddingKaldi  PaddingMode = "kaldi"  // Kaldi `snip_edges=false`: ramka markazlari (t+0.5)·HopLength da, chetlar symmetric aks
+)
+
+// PadMode - To‘ldirilgan namunalar qiymatini aniqlash usuli
+type PadMode string
+
+const (
+	PadReflect   PadMode = "reflect"   // Chegara namunasisiz ko‘zgu aksi (numpy `reflect`)
+	PadConstant  PadMode = "constant"  // Nollar bilan to‘ldirish
+	PadSymmetric PadMode = "symmetric" // Chegara namunasini ham o‘z ichiga olgan ko‘zgu aksi (numpy `symmetric`, Kaldi)
+	PadEdge      PadMode = "edge"      // Chegara namunasini takrorlash (numpy `edge`)
+)
+
+// validatePadding - To‘ldirish sozlamalarini tekshirish
+func validatePadding(padding PaddingMode, mode PadMode) error {
+	switch padding {
+	case "", PaddingNone, PaddingCenter, PaddingTail, PaddingKaldi:
+	default:
+		return fmt.Errorf("unknown padding %q", padding)
+	}
+	switch mode {
+	case "", PadReflect, PadConstant, PadSymmetric, PadEdge:
+	default:
+		return fmt.Errorf("unknown pad mode %q", mode)
+	}
+	return nil
+}
+
+// padSignal - Signalni chapdan left, o‘ngdan right namuna bilan to‘ldirish
+func padSignal(signal []float32, left, right int, mode PadMode) []float32 {
+	n := len(signal)
+	padded := make([]float32, left+n+right)
+	copy(padded[left:], signal)
+	if mode == PadConstant || n == 0 {
+		return padded
+	}
+
+	index := reflectIndex
+	switch mode {
+	case PadSymmetric:
+		index = symmetricIndex
+	case PadEdge:
+		index = edgeIndex
+	}
+	for i := 0; i < left; i++ {
+		padded[i] = signal[index(i-left, n)]
+	}
+	for i := 0; i < right; i++ {
+		padded[left+n+i] = signal[index(n+i, n)]
+	}
+	return padded
+}
+
+// kaldiFrameLayout - Kaldi `snip_edges=false` uchun ramkalar soni va birinchi ramka boshlanishi.
+// Birinchi ramka manfiy indeksdan boshlanishi mumkin, bu namunalar symmetric aks bilan to‘ldiriladi.
+func kaldiFrameLayout(numSamples, frameLength, hopLength int) (numFrames, firstStart int) {
+	numFrames = (numSamples + hopLength/2) / hopLength
+	firstStart = hopLength/2 - frameLength/2
+	return numFrames, firstStart
+}
+
+// reflectIndex - Chegaradan tashqaridagi indeksni numpy `reflect` qoidasi bo‘yicha ichkariga qaytarish.
+// Kerak bo‘lsa aks bir necha marta takrorlanadi (davr 2(n-1)).
+func reflectIndex(i, n int) int {
+	if n == 1 {
+		return 0
+	}
+	period := 2 * (n - 1)
+	i %= period
+	if i < 0 {
+		i += period
+	}
+	if i >= n {
+		i = period - i
+	}
+	return i
+}
+
+// symmetricIndex - Chegaradan tashqaridagi indeksni numpy `symmetric` qoidasi bo‘yicha ichkariga qaytarish
+// (chegara namunasi takrorlanadi, davr 2n). Kaldi `ExtractWindow` bilan bir xil.
+func symmetricIndex(i, n int) int {
+	period := 2 * n
+	i %= period
+	if i < 0 {
+		i += period
+	}
+	if i >= n {
+		i = period - 1 - i
+	}
+	return i
+}
+
+// edgeIndex - Chegaradan tashqaridagi indeksni eng yaqin chegara namunasiga keltirish (numpy `edge`)
+func edgeIndex(i, n int) int {
+	return min(max(i, 0), n-1)
+}
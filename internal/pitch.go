@@ -0,0 +1,501 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+)
+
+// PitchMethod - Fundamental chastotani (f0) aniqlash algoritmi
+type PitchMethod string
+
+const (
+	PitchYIN  PitchMethod = "yin"  // YIN (de Cheveigné va Kawahara, 2002): chegaradan past birinchi CMNDF minimumi
+	PitchPYIN PitchMethod = "pyin" // Ehtimoliy YIN (Mauch va Dixon, 2014): nomzodlar ehtimoli va ramkalar bo‘yicha Viterbi
+)
+
+// Pitch standart qiymatlari va pYIN parametrlari (librosa `pyin` bilan bir xil)
+const (
+	defaultPitchFmax      = 400.0 // Hz
+	defaultPitchFmin      = 50.0  // Hz, ramka uzunligi ruxsat bersa
+	defaultPitchThreshold = 0.1   // YIN absolyut chegarasi
+
+	pyinThresholds        = 100   // CMNDF chegaralari soni (0.01 ... 1)
+	pyinBetaA, pyinBetaB  = 2, 18 // Chegaralar beta taqsimoti parametrlari (o‘rtacha 0.1)
+	pyinBoltzmann         = 2.0   // Chegaradan past minimumlar tartibi bo‘yicha Boltzmann parametri
+	pyinNoTroughProb      = 0.01  // Hech bir minimum chegaradan past bo‘lmaganda global minimum ehtimoli
+	pyinBinsPerSemitone   = 10    // Viterbi pitch bin’lari zichligi
+	pyinMaxTransitionRate = 35.92 // Pitch o‘zgarishining maksimal tezligi (oktava/soniya)
+	pyinSwitchProb        = 0.01  // Ovozli va ovozsiz holatlar orasida o‘tish ehtimoli
+)
+
+// validatePitchOptions - Pitch sozlamalarini tekshirish (ramka uzunligiga bog‘liq chegara ham)
+func validatePitchOptions(c *Config) error {
+	switch c.PitchMethod {
+	case "", PitchYIN, PitchPYIN:
+	default:
+		return fmt.Errorf("unknown pitch method %q", c.PitchMethod)
+	}
+	if c.PitchFmin < 0 || c.PitchFmax < 0 {
+		return fmt.Errorf("pitch frequency range must be non-negative")
+	}
+	if c.PitchThreshold < 0 || c.PitchThreshold >= 1 {
+		return fmt.Errorf("pitch threshold must be in [0, 1) range")
+	}
+	if c.PitchFmax > 0 && float64(c.PitchFmax) >= float64(c.SampleRate)/2 {
+		return fmt.Errorf("pitch fmax must be below the Nyquist frequency")
+	}
+	if c.PitchFmin > 0 {
+		if need := 2 * int(math.Ceil(float64(c.SampleRate)/float64(c.PitchFmin))); need > c.FrameLength {
+			return fmt.Errorf("pitch fmin %g Hz needs frame length of at least %d samples", c.PitchFmin, need)
+		}
+	}
+	if c.PitchFmin > 0 && c.PitchFmax > 0 && c.PitchFmin >= c.PitchFmax {
+		return fmt.Errorf("pitch fmin must be below pitch fmax")
+	}
+	return nil
+}
+
+// pitchCandidate - pYIN nomzodi: CMNDF minimumidan olingan chastota va uning ehtimoli
+type pitchCandidate struct {
+	freq float32
+	prob float32
+}
+
+// pitchEstimate - Bitta ramka uchun pitch tahlili natijasi
+type pitchEstimate struct {
+	pitch      float32 // Hz, ovozsiz ramkada 0
+	voiced     bool
+	voicedProb float32
+	candidates []pitchCandidate // pYIN nomzodlari (Viterbi uchun)
+}
+
+// pitchTracker - Ramkalar bo‘yicha YIN/pYIN hisoblagich. Farq funksiyasi FFT orqali O(N log N) da hisoblanadi:
+// d(τ) = Σx²[j] + Σx²[j+τ] − 2·Σx[j]·x[j+τ], j < W, bu yerda W = N − τmax (librosa kabi qat’iy oyna).
+type pitchTracker struct {
+	method      PitchMethod
+	sampleRate  float64
+	frameLength int
+	window      int // W - yig‘indi oynasi uzunligi
+	tauMin      int
+	tauMax      int
+	threshold   float64
+	fft         *complexFFT // N uzunlikdagi kompleks FFT (korrelyatsiya siklik o‘ralmaydi, chunki j+τ < N)
+
+	// pYIN
+	betaProbs []float64 // Har bir chegara oralig‘ining beta taqsimoti bo‘yicha ehtimoli
+	fmin      float64   // Viterbi bin’lari boshlanishi
+	numBins   int
+	halfWidth int       // Ramkalar orasida pitch o‘zgarishi mumkin bo‘lgan bin’lar soni
+	logWeight []float64 // log uchburchak o‘tish og‘irligi |Δbin| bo‘yicha
+	logNorm   []float64 // Har bir bin’dan chiquvchi o‘tishlar yig‘indisining logarifmi
+}
+
+// pitchBuffer - Bitta goroutine’ning pitch hisoblashdagi vaqtinchalik buferlari
+type pitchBuffer struct {
+	in, spec, scratch []complex64
+	energy            []float64 // x² ning prefiks yig‘indilari
+	cmnd              []float64 // CMNDF, τ = 0..τmax
+}
+
+// newPitchTracker - Konfiguratsiya bo‘yicha pitch hisoblagich yaratish.
+// f0 oralig‘i ramka uzunligiga sig‘masa (juda qisqa ramkalar) nil qaytaradi va pitch 0 bo‘lib qoladi.
+func newPitchTracker(cfg Config) *pitchTracker {
+	sr := float64(cfg.SampleRate)
+	n := cfg.FrameLength
+	fmax := float64(cfg.PitchFmax)
+	if fmax == 0 {
+		fmax = min(defaultPitchFmax, sr/4)
+	}
+	fmin := float64(cfg.PitchFmin)
+	if fmin == 0 {
+		// Eng past f0 davri ramkaning yarmidan oshmasligi kerak
+		fmin = max(defaultPitchFmin, 2*sr/float64(n))
+	}
+	tauMin := max(1, int(sr/fmax))
+	tauMax := min(int(math.Ceil(sr/fmin)), n/2)
+	if fmin >= fmax || tauMax-tauMin < 2 {
+		return nil
+	}
+
+	threshold := float64(cfg.PitchThreshold)
+	if threshold == 0 {
+		threshold = defaultPitchThreshold
+	}
+	t := &pitchTracker{
+		method:      cfg.PitchMethod,
+		sampleRate:  sr,
+		frameLength: n,
+		window:      n - tauMax,
+		tauMin:      tauMin,
+		tauMax:      tauMax,
+		threshold:   threshold,
+		fft:         newComplexFFT(n),
+	}
+	if t.method == "" {
+		t.method = PitchYIN
+	}
+	if t.method == PitchPYIN {
+		t.initPYIN(fmin, fmax, cfg.HopLength)
+	}
+	return t
+}
+
+// initPYIN - Chegaralar taqsimoti va Viterbi o‘tish og‘irliklarini oldindan hisoblash
+func (t *pitchTracker) initPYIN(fmin, fmax float64, hopLength int) {
+	t.betaProbs = make([]float64, pyinThresholds)
+	prev := 0.0
+	for i := range t.betaProbs {
+		cdf := betaCDF(float64(i+1)/pyinThresholds, pyinBetaA, pyinBetaB)
+		t.betaProbs[i] = cdf - prev
+		prev = cdf
+	}
+
+	t.fmin = fmin
+	t.numBins = int(12*pyinBinsPerSemitone*math.Log2(fmax/fmin)) + 1
+	semitones := math.Round(pyinMaxTransitionRate * 12 * float64(hopLength) / t.sampleRate)
+	t.halfWidth = int(semitones) * pyinBinsPerSemitone / 2
+	// scipy `get_window('triangle', 2h+1)`: w(Δ) = 1 − |Δ|/(h+1)
+	t.logWeight = make([]float64, t.halfWidth+1)
+	for d := range t.logWeight {
+		t.logWeight[d] = math.Log(1 - float64(d)/float64(t.halfWidth+1))
+	}
+	t.logNorm = make([]float64, t.numBins)
+	for b := range t.logNorm {
+		var sum float64
+		for d := -t.halfWidth; d <= t.halfWidth; d++ {
+			if b+d >= 0 && b+d < t.numBins {
+				sum += math.Exp(t.logWeight[abs(d)])
+			}
+		}
+		t.logNorm[b] = math.Log(sum)
+	}
+}
+
+// newBuffer - Hisoblagich o‘lchamlaridagi vaqtinchalik buferlar
+func (t *pitchTracker) newBuffer() *pitchBuffer {
+	n := t.frameLength
+	return &pitchBuffer{
+		in:      make([]complex64, n),
+		spec:    make([]complex64, n),
+		scratch: make([]complex64, t.fft.maxRadix),
+		energy:  make([]float64, n+1),
+		cmnd:    make([]float64, t.tauMax+1),
+	}
+}
+
+// analyze - Bitta ramkaning pitch bahosi. pYIN da ramka alohida dekodlanadi (eng ehtimolli nomzod);
+// butun signal uchun Viterbi silliqlash decode da bajariladi.
+func (t *pitchTracker) analyze(frame []float32, buf *pitchBuffer) pitchEstimate {
+	if len(frame) != t.frameLength {
+		return pitchEstimate{}
+	}
+	cmnd := t.cmndf(frame, buf)
+	if cmnd == nil {
+		return pitchEstimate{}
+	}
+	if t.method == PitchYIN {
+		return t.yin(cmnd)
+	}
+
+	est := pitchEstimate{candidates: t.pyinCandidates(cmnd)}
+	var best pitchCandidate
+	for _, c := range est.candidates {
+		est.voicedProb += c.prob
+		if c.prob > best.prob {
+			best = c
+		}
+	}
+	est.voicedProb = min(est.voicedProb, 1)
+	if est.voicedProb >= 0.5 {
+		est.pitch, est.voiced = best.freq, true
+	}
+	return est
+}
+
+// cmndf - FFT orqali farq funksiyasi va uning kumulyativ o‘rtacha bo‘yicha normallashtirilgan ko‘rinishi (CMNDF).
+// Raqamli jimjitlikda (energiya 0) nil qaytaradi.
+func (t *pitchTracker) cmndf(frame []float32, buf *pitchBuffer) []float64 {
+	n, w := t.frameLength, t.window
+
+	buf.energy[0] = 0
+	for j, v := range frame {
+		buf.energy[j+1] = buf.energy[j] + float64(v)*float64(v)
+	}
+	if buf.energy[n] == 0 {
+		return nil
+	}
+
+	// a = x[0:W] (nollar bilan), b = x[0:N] ni bitta kompleks FFT da: z = a + i·b
+	for j, v := range frame {
+		a := float32(0)
+		if j < w {
+			a = v
+		}
+		buf.in[j] = complex(a, v)
+	}
+	t.fft.transform(buf.in, buf.spec, buf.scratch)
+
+	// Kross-korrelyatsiya spektri: conj(A)·B, teskari FFT o‘rniga conj(...) ning to‘g‘ri FFT si olinadi
+	for k := range buf.in {
+		zk := buf.spec[k]
+		znk := buf.spec[(n-k)%n]
+		znk = complex(real(znk), -imag(znk))
+		a := 0.5 * (zk + znk)
+		b := complex(0, -0.5) * (zk - znk)
+		buf.in[k] = a * complex(real(b), -imag(b))
+	}
+	t.fft.transform(buf.in, buf.spec, buf.scratch)
+
+	cmnd := buf.cmnd
+	cmnd[0] = 1
+	var sum float64
+	for tau := 1; tau <= t.tauMax; tau++ {
+		corr := float64(real(buf.spec[tau])) / float64(n)
+		d := buf.energy[w] + buf.energy[tau+w] - buf.energy[tau] - 2*corr
+		d = max(d, 0)
+		sum += d
+		if sum > 0 {
+			cmnd[tau] = d * float64(tau) / sum
+		} else {
+			cmnd[tau] = 1
+		}
+	}
+	return cmnd
+}
+
+// yin - Chegaradan past birinchi minimum; topilmasa ramka ovozsiz
+func (t *pitchTracker) yin(cmnd []float64) pitchEstimate {
+	best := t.tauMin
+	for tau := t.tauMin; tau <= t.tauMax; tau++ {
+		if cmnd[tau] < t.threshold {
+			for tau < t.tauMax && cmnd[tau+1] < cmnd[tau] {
+				tau++
+			}
+			return pitchEstimate{
+				pitch:      float32(t.sampleRate / t.refine(cmnd, tau)),
+				voiced:     true,
+				voicedProb: float32(1 - cmnd[tau]),
+			}
+		}
+		if cmnd[tau] < cmnd[best] {
+			best = tau
+		}
+	}
+	return pitchEstimate{voicedProb: float32(max(0, 1-cmnd[best]))}
+}
+
+// pyinCandidates - CMNDF minimumlarini chegaralar taqsimoti bo‘yicha ehtimollar bilan nomzodlarga aylantirish
+func (t *pitchTracker) pyinCandidates(cmnd []float64) []pitchCandidate {
+	var troughs []int
+	for tau := t.tauMin; tau <= t.tauMax; tau++ {
+		// Oraliq chetlari ham bitta qo‘shnisidan past bo‘lsa minimum hisoblanadi
+		left := tau == t.tauMin || cmnd[tau] < cmnd[tau-1]
+		right := tau == t.tauMax || cmnd[tau] <= cmnd[tau+1]
+		if left && right {
+			troughs = append(troughs, tau)
+		}
+	}
+	if len(troughs) == 0 {
+		return nil
+	}
+
+	probs := make([]float64, len(troughs))
+	global := 0
+	for i, tau := range troughs {
+		if cmnd[tau] < cmnd[troughs[global]] {
+			global = i
+		}
+	}
+	for s, beta := range t.betaProbs {
+		threshold := float64(s+1) / pyinThresholds
+		below := 0
+		for _, tau := range troughs {
+			if cmnd[tau] < threshold {
+				below++
+			}
+		}
+		if below == 0 {
+			// Hech bir minimum chegaradan past emas: global minimumga kichik ehtimol
+			probs[global] += pyinNoTroughProb * beta
+			continue
+		}
+		// Chegaradan past minimumlar orasida oldingilari (katta chastotalar) Boltzmann bo‘yicha afzal
+		position := 0
+		for i, tau := range troughs {
+			if cmnd[tau] < threshold {
+				probs[i] += beta * boltzmannPMF(position, pyinBoltzmann, below)
+				position++
+			}
+		}
+	}
+
+	candidates := make([]pitchCandidate, 0, len(troughs))
+	for i, tau := range troughs {
+		if probs[i] > 0 {
+			candidates = append(candidates, pitchCandidate{
+				freq: float32(t.sampleRate / t.refine(cmnd, tau)),
+				prob: float32(probs[i]),
+			})
+		}
+	}
+	return candidates
+}
+
+// refine - Minimum atrofida parabolik interpolyatsiya bilan kasr davr
+func (t *pitchTracker) refine(cmnd []float64, tau int) float64 {
+	if tau <= 1 || tau >= t.tauMax {
+		return float64(tau)
+	}
+	a, b, c := cmnd[tau-1], cmnd[tau], cmnd[tau+1]
+	denom := a - 2*b + c
+	if denom <= 0 {
+		return float64(tau)
+	}
+	shift := 0.5 * (a - c) / denom
+	return float64(tau) + max(-1, min(1, shift))
+}
+
+// decode - pYIN: ramkalar bo‘yicha Viterbi bilan ovozli/ovozsiz holat va pitch bin’ini tanlash.
+// Natija estimates ga yoziladi; ovozli ramkada pitch tanlangan bin’dagi eng ehtimolli nomzod chastotasi.
+func (t *pitchTracker) decode(estimates []pitchEstimate) {
+	if t.method != PitchPYIN || len(estimates) == 0 {
+		return
+	}
+	bins := t.numBins
+	states := 2 * bins
+	logStay := math.Log(1 - pyinSwitchProb)
+	logSwitch := math.Log(pyinSwitchProb)
+
+	prev := make([]float64, states)
+	cur := make([]float64, states)
+	emission := make([]float64, states)
+	back := make([]uint16, len(estimates)*states)
+
+	for f := range estimates {
+		t.emissions(estimates[f], emission)
+		if f == 0 {
+			for s := range cur {
+				cur[s] = -math.Log(float64(states)) + emission[s]
+			}
+			prev, cur = cur, prev
+			continue
+		}
+		ptr := back[f*states : (f+1)*states]
+		for b := 0; b < bins; b++ {
+			bestV, bestU := math.Inf(-1), math.Inf(-1)
+			var fromV, fromU int
+			for d := -t.halfWidth; d <= t.halfWidth; d++ {
+				src := b - d
+				if src < 0 || src >= bins {
+					continue
+				}
+				w := t.logWeight[abs(d)] - t.logNorm[src]
+				if v := prev[src] + logStay + w; v > bestV {
+					bestV, fromV = v, src
+				}
+				if v := prev[bins+src] + logSwitch + w; v > bestV {
+					bestV, fromV = v, bins+src
+				}
+				if v := prev[src] + logSwitch + w; v > bestU {
+					bestU, fromU = v, src
+				}
+				if v := prev[bins+src] + logStay + w; v > bestU {
+					bestU, fromU = v, bins+src
+				}
+			}
+			cur[b], ptr[b] = bestV+emission[b], uint16(fromV)
+			cur[bins+b], ptr[bins+b] = bestU+emission[bins+b], uint16(fromU)
+		}
+		prev, cur = cur, prev
+	}
+
+	state := 0
+	for s := range prev {
+		if prev[s] > prev[state] {
+			state = s
+		}
+	}
+	for f := len(estimates) - 1; f >= 0; f-- {
+		est := &estimates[f]
+		est.voiced = state < bins
+		est.pitch = 0
+		if est.voiced {
+			est.pitch = t.binPitch(*est, state)
+		}
+		if f > 0 {
+			state = int(back[f*states+state])
+		}
+	}
+}
+
+// emissions - Ramka nomzodlaridan har bir holatning log ehtimoli: ovozli bin’lar - nomzodlar ehtimoli,
+// ovozsiz bin’lar - (1 − ovozlilik ehtimoli) bin’lar bo‘yicha teng taqsimlangan
+func (t *pitchTracker) emissions(est pitchEstimate, out []float64) {
+	bins := t.numBins
+	voiced := out[:bins]
+	clear(voiced)
+	for _, c := range est.candidates {
+		voiced[t.bin(float64(c.freq))] += float64(c.prob)
+	}
+	const floor = 1e-300
+	for b := range voiced {
+		voiced[b] = math.Log(max(voiced[b], floor))
+	}
+	unvoiced := math.Log(max((1-float64(est.voicedProb))/float64(bins), floor))
+	for b := bins; b < len(out); b++ {
+		out[b] = unvoiced
+	}
+}
+
+// bin - Chastotaning Viterbi bin’i (0.1 yarim ton qadam bilan)
+func (t *pitchTracker) bin(freq float64) int {
+	b := int(math.Round(12 * pyinBinsPerSemitone * math.Log2(freq/t.fmin)))
+	return max(0, min(t.numBins-1, b))
+}
+
+// binPitch - Tanlangan bin’dagi eng ehtimolli nomzod chastotasi (nomzod bo‘lmasa bin markazi)
+func (t *pitchTracker) binPitch(est pitchEstimate, b int) float32 {
+	var best pitchCandidate
+	for _, c := range est.candidates {
+		if t.bin(float64(c.freq)) == b && c.prob > best.prob {
+			best = c
+		}
+	}
+	if best.prob > 0 {
+		return best.freq
+	}
+	return float32(t.fmin * math.Exp2(float64(b)/(12*pyinBinsPerSemitone)))
+}
+
+// betaCDF - Butun a, b parametrli beta taqsimotining CDF i (regulyarlashgan to‘liqmas beta funksiyasi):
+// I_x(a, b) = Σ_{j=a}^{a+b−1} C(a+b−1, j)·x^j·(1−x)^(a+b−1−j)
+func betaCDF(x float64, a, b int) float64 {
+	n := a + b - 1
+	var sum float64
+	for j := a; j <= n; j++ {
+		sum += binomial(n, j) * math.Pow(x, float64(j)) * math.Pow(1-x, float64(n-j))
+	}
+	return sum
+}
+
+// binomial - Binomial koeffitsient C(n, k)
+func binomial(n, k int) float64 {
+	c := 1.0
+	for i := 1; i <= k; i++ {
+		c = c * float64(n-k+i) / float64(i)
+	}
+	return c
+}
+
+// boltzmannPMF - Kesilgan Boltzmann taqsimoti: P(k) = (1 − e^(−λ))·e^(−λk) / (1 − e^(−λN)), k < N
+func boltzmannPMF(k int, lambda float64, n int) float64 {
+	return (1 - math.Exp(-lambda)) * math.Exp(-lambda*float64(k)) / (1 - math.Exp(-lambda*float64(n)))
+}
+
+// abs - Butun sonning moduli
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -1,9 +1,11 @@
 package internal
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"runtime"
+	"math"
+	"math/rand/v2"
 	"sync"
 )
 
@@ -11,20 +13,43 @@ import (
 // Bu tuzilma model o‘qitish uchun barcha xususiyatlarni jamlaydi
 type FrameFeatures struct {
 	MFCC             []float32 // MFCC koeffitsientlari
+	Delta            []float32 // MFCC ning birinchi tartibli deltasi (DeltaOrder >= 1 bo‘lsa)
+	DeltaDelta       []float32 // MFCC ning ikkinchi tartibli deltasi (DeltaOrder >= 2 bo‘lsa)
 	ZCR              float32   // Zero-Crossing Rate
-	Pitch            float32   // Fundamental chastota
+	Pitch            float32   // Fundamental chastota (Hz, ovozsiz ramkada 0)
+	Voiced           bool      // Ramka ovozli (pitch aniqlangan)
+	VoicedProb       float32   // Ovozlilik ehtimoli: pYIN da nomzodlar ehtimoli yig‘indisi, YIN da 1 − CMNDF minimumi
 	SpectralCentroid float32   // Spectral Centroid
 	SpectralRollOff  float32   // Spectral Roll-off
 	Energy           float32   // Ramka energiyasi
 }
 
+// Vector - MFCC, delta va delta-delta koeffitsientlarini bitta vektorga birlashtirish
+func (f FrameFeatures) Vector() []float32 {
+	if len(f.Delta) == 0 && len(f.DeltaDelta) == 0 {
+		return f.MFCC
+	}
+	vec := make([]float32, 0, len(f.MFCC)+len(f.Delta)+len(f.DeltaDelta))
+	vec = append(vec, f.MFCC...)
+	vec = append(vec, f.Delta...)
+	vec = append(vec, f.DeltaDelta...)
+	return vec
+}
+
 // Processor - Audio xususiyatlarini hisoblash uchun asosiy tuzilma
 type Processor struct {
 	config      Config
 	filterBanks [][]float32
+	dct         *DCTMatrix // DCT bazisi (backend bilan umumiy)
 	window      []float32
-	memPool     *MemoryPool
-	gpuCtx      *GPUContext
+	backend     Backend        // Asosiy hisoblash backend’i
+	fallback    Backend        // Asosiy backend xatolik bersa ishlatiladigan CPU backend (ixtiyoriy)
+	deltas      *deltaComputer // Delta hisoblagich (DeltaOrder > 0 bo‘lsa)
+	cmvnStats   *CMVNStats     // Global CMVN statistikasi
+	lifter      []float32      // Kepstral lifter koeffitsientlari (TopDB qayta hisoblashi uchun)
+	sched       *scheduler     // Parallel hisoblash uchun umumiy ishchilar byudjeti
+	pitch       *pitchTracker  // YIN/pYIN hisoblagich (pitch so‘ralmagan yoki f0 oralig‘i ramkaga sig‘masa nil)
+	features    FeatureSet     // Process hisoblaydigan xususiyatlar (Config.Features, 0 bo‘lsa barchasi)
 	mu          sync.Mutex
 }
 
@@ -35,162 +60,537 @@ func NewProcessor(cfg Config) (*Processor, error) {
 	}
 
 	// Mel filtrlarini yaratish
-	filterBanks := createMelFilterBanks(cfg.SampleRate, cfg.FrameLength, cfg.NumFilters, cfg.LowFreq, cfg.HighFreq)
+	filterBanks := createMelFilterBanks(cfg)
 	// Oyna funksiyasini yaratish
-	window := createWindow(cfg.FrameLength, cfg.WindowType)
+	window := createWindow(cfg.FrameLength, cfg.WindowType, cfg.PeriodicWindow)
+
+	// Siyrak filtrlar va DCT bazisi bir marta hisoblanib, barcha backend’larga uzatiladi
+	dct := NewDCTMatrix(cfg.NumFilters, cfg.NumCoefficients)
+	backend, fallback, err := openBackends(BackendSpec{
+		Config:      cfg,
+		FilterBanks: filterBanks,
+		MelFilters:  NewMelFilterBank(filterBanks),
+		DCT:         dct,
+	})
+	if err != nil {
+		return nil, err
+	}
 
-	var gpuCtx *GPUContext
-	var err error
-	if cfg.UseGPU {
-		gpuCtx, err = NewGPUContext(cfg.FrameLength, cfg.NumFilters, cfg.NumCoefficients)
-		if err != nil {
-			return nil, fmt.Errorf("GPU kontekstini yaratishda xatolik: %w", err)
+	var deltas *deltaComputer
+	if cfg.DeltaOrder > 0 {
+		deltas = newDeltaComputer(cfg.DeltaOrder, cfg.DeltaWindow)
+	}
+
+	var cmvnStats *CMVNStats
+	if cfg.CMVNStatsFile != "" {
+		if cmvnStats, err = LoadCMVNStats(cfg.CMVNStatsFile); err != nil {
+			backend.Close()
+			return nil, err
 		}
 	}
 
+	var pitch *pitchTracker
+	if cfg.featureSet().Has(FeaturePitch) {
+		pitch = newPitchTracker(cfg)
+	}
+
 	return &Processor{
 		config:      cfg,
 		filterBanks: filterBanks,
+		dct:         dct,
 		window:      window,
-		memPool:     NewMemoryPool(cfg.MaxConcurrency, cfg.FrameLength, cfg.NumFilters, cfg.NumCoefficients),
-		gpuCtx:      gpuCtx,
+		backend:     backend,
+		fallback:    fallback,
+		deltas:      deltas,
+		cmvnStats:   cmvnStats,
+		lifter:      createLifter(cfg.NumCoefficients, cfg.CepLifter),
+		sched:       newScheduler(cfg.MaxConcurrency),
+		pitch:       pitch,
+		features:    cfg.featureSet(),
 	}, nil
 }
 
+// openBackends - Konfiguratsiya bo‘yicha asosiy va zaxira backend’larni yaratish
+func openBackends(spec BackendSpec) (backend, fallback Backend, err error) {
+	name := backendName(spec.Config)
+	backend, err = newBackend(name, spec)
+	if err != nil {
+		if !spec.Config.BackendFallback || name == BackendCPU {
+			if name == BackendCUDA {
+				return nil, nil, fmt.Errorf("GPU kontekstini yaratishda xatolik: %w", err)
+			}
+			return nil, nil, fmt.Errorf("%q backend’ni yaratishda xatolik: %w", name, err)
+		}
+		// Asosiy backend ochilmadi, CPU’ga o‘tamiz
+		backend, err = NewCPUBackend(spec)
+		return backend, nil, err
+	}
+
+	if spec.Config.BackendFallback && name != BackendCPU {
+		fallback, err = NewCPUBackend(spec)
+		if err != nil {
+			backend.Close()
+			return nil, nil, err
+		}
+	}
+	return backend, fallback, nil
+}
+
 // Config - Protsessor konfiguratsiyasini qaytaradi
 func (p *Processor) Config() Config {
 	return p.config
 }
 
-// Process - Audio signalni qayta ishlaydi va barcha xususiyatlarni hisoblaydi
+// FilterBanks - Mel filtrlar banki nusxasini qaytaradi
+func (p *Processor) FilterBanks() [][]float32 {
+	out := make([][]float32, len(p.filterBanks))
+	for i, filter := range p.filterBanks {
+		out[i] = append([]float32(nil), filter...)
+	}
+	return out
+}
+
+// BackendName - Ishlatilayotgan backend nomini qaytaradi
+func (p *Processor) BackendName() string {
+	return p.backend.Name()
+}
+
+// SetCMVNStats - Global CMVN uchun statistikani o‘rnatish
+func (p *Processor) SetCMVNStats(stats *CMVNStats) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.cmvnStats = stats
+}
+
+// AccumulateCMVN - Audio signalning normallashtirilmagan MFCC larini statistikaga qo‘shish
+func (p *Processor) AccumulateCMVN(stats *CMVNStats, audio []float32) error {
+	features, err := p.extract(context.Background(), audio, FeatureMFCC)
+	if err != nil {
+		return err
+	}
+	mfccs := make([][]float32, len(features))
+	for i, f := range features {
+		mfccs[i] = f.MFCC
+	}
+	return stats.Accumulate(mfccs)
+}
+
+// Process - Audio signalni qayta ishlaydi va Config.Features dagi xususiyatlarni hisoblaydi
 func (p *Processor) Process(audio []float32) ([]FrameFeatures, error) {
-	if len(audio) == 0 {
-		return nil, errors.New("audio kirishi bo‘sh")
+	return p.ProcessContext(context.Background(), audio)
+}
+
+// ProcessContext - Process kabi, lekin ctx bekor qilinsa ramkalarni hisoblash to‘xtatiladi va ctx.Err() o‘ralgan xatolik qaytariladi
+func (p *Processor) ProcessContext(ctx context.Context, audio []float32) ([]FrameFeatures, error) {
+	return p.ProcessFeatures(ctx, audio, p.features)
+}
+
+// ProcessFeatures - Faqat want dagi xususiyatlarni hisoblash; qolgan FrameFeatures maydonlari nol bo‘lib qoladi.
+// CMVN va deltalar faqat MFCC so‘ralganda qo‘llanadi.
+func (p *Processor) ProcessFeatures(ctx context.Context, audio []float32, want FeatureSet) ([]FrameFeatures, error) {
+	features, err := p.extract(ctx, audio, want)
+	if err != nil {
+		return nil, err
+	}
+	if !want.Has(FeatureMFCC) {
+		return features, nil
 	}
 
-	// Pre-emphasis qo‘llash
-	emphasized := p.applyPreEmphasis(audio)
-	// Signalni ramkalarga bo‘lish
-	frames := p.frameSignal(emphasized)
+	// CMVN ni qo‘llash (deltalardan oldin, Kaldi tartibida)
+	if err := p.applyCMVN(features); err != nil {
+		return nil, err
+	}
 
-	var features []FrameFeatures
-	var err error
+	// Delta koeffitsientlarini hisoblash
+	if p.deltas != nil {
+		p.deltas.apply(features)
+	}
 
-	switch {
-	case p.config.UseGPU && p.gpuCtx != nil:
-		// GPU’da faqat MFCC hisoblanadi, qolgan xususiyatlar CPU’da
-		mfccs, err := p.gpuCtx.ComputeMFCC(frames, p.filterBanks, p.window, p.config)
-		if err != nil {
-			return nil, fmt.Errorf("GPU’da MFCC hisoblashda xatolik: %w", err)
-		}
-		features = make([]FrameFeatures, len(frames))
-		for i, frame := range frames {
-			powerSpectrum := computePowerSpectrum(frame)
-			features[i] = FrameFeatures{
-				MFCC:             mfccs[i],
-				ZCR:              computeZCR(frame),
-				Pitch:            computePitch(frame, float32(p.config.SampleRate)),
-				SpectralCentroid: computeSpectralCentroid(powerSpectrum, float32(p.config.SampleRate)),
-				SpectralRollOff:  computeSpectralRollOff(powerSpectrum, float32(p.config.SampleRate), 0.85),
-				Energy:           computeEnergy(frame),
-			}
+	return features, nil
+}
+
+// applyCMVN - Konfiguratsiyadagi CMVN turini MFCC larga joyida qo‘llash
+func (p *Processor) applyCMVN(features []FrameFeatures) error {
+	mfccs := make([][]float32, len(features))
+	for i, f := range features {
+		mfccs[i] = f.MFCC
+	}
+
+	switch p.config.CMVN {
+	case CMVNUtterance:
+		utteranceCMVN(mfccs, p.config.CMVNNormVars)
+	case CMVNSliding:
+		slidingCMVN(mfccs, p.config.CMVNWindow, p.config.CMVNCenter, p.config.CMVNNormVars)
+	case CMVNGlobal:
+		p.mu.Lock()
+		stats := p.cmvnStats
+		p.mu.Unlock()
+		if stats == nil {
+			return ErrNoCMVNStats
+		}
+		if err := stats.apply(mfccs, p.config.CMVNNormVars); err != nil {
+			return fmt.Errorf("global CMVN ni qo‘llashda xatolik: %w", err)
+		}
+	}
+	return nil
+}
+
+// extract - Audio signaldan normalizatsiya va deltalarsiz want dagi ramka xususiyatlarini hisoblash
+func (p *Processor) extract(ctx context.Context, audio []float32, want FeatureSet) ([]FrameFeatures, error) {
+	frames, err := p.prepareFrames(audio)
+	if err != nil {
+		return nil, err
+	}
+	// Pitch pre-emphasis siz signalda aniqlanadi: yuqori chastota filtri f0 ni garmonikalarga nisbatan susaytirib,
+	// oktava xatolariga olib keladi (librosa `pyin` ham xom signalda ishlaydi)
+	var pitchFrames [][]float32
+	if want.Has(FeaturePitch) && p.pitch != nil && p.signalPreEmphasis() {
+		if pitchFrames, err = p.frameSignal(p.scaleSamples(audio)); err != nil {
+			return nil, err
 		}
-	case p.config.Parallel:
-		features = p.processParallel(frames)
-	default:
-		features = p.processSequential(frames)
 	}
 
+	features := make([]FrameFeatures, len(frames))
+	// top_db butun audio bo‘yicha maksimumga bog‘liq, shuning uchun log-Mel saqlab qo‘yiladi
+	var logMel [][]float32
+	if p.topDBActive() && want.Has(FeatureMFCC) {
+		logMel = make([][]float32, len(frames))
+	}
+	// pYIN Viterbi butun signal bo‘yicha ishlaydi, shuning uchun ramkalar nomzodlari saqlab qo‘yiladi
+	var pitch []pitchEstimate
+	if want.Has(FeaturePitch) && p.pitch != nil && p.pitch.method == PitchPYIN {
+		pitch = make([]pitchEstimate, len(frames))
+	}
+	err = p.forEachChunk(ctx, len(frames), func(start, end int) error {
+		var chunkLogMel [][]float32
+		if logMel != nil {
+			chunkLogMel = logMel[start:end]
+		}
+		var chunkPitch []pitchEstimate
+		if pitch != nil {
+			chunkPitch = pitch[start:end]
+		}
+		var chunkPitchFrames [][]float32
+		if pitchFrames != nil {
+			chunkPitchFrames = pitchFrames[start:end]
+		}
+		return p.processChunk(frames[start:end], features[start:end], want, chunkLogMel, chunkPitch, chunkPitchFrames)
+	})
 	if err != nil {
 		return nil, fmt.Errorf("xususiyatlarni hisoblashda xatolik: %w", err)
 	}
 
+	if pitch != nil {
+		p.pitch.decode(pitch)
+		for i, est := range pitch {
+			features[i].Pitch, features[i].Voiced = est.pitch, est.voiced
+		}
+	}
+
+	if logMel != nil {
+		// top_db kesishdan keyin MFCC ni qayta hisoblash
+		clampTopDB(logMel, p.config.TopDB)
+		for i := range features {
+			mfcc := p.cepstrum(logMel[i])
+			if p.config.UseEnergy {
+				mfcc[0] = features[i].MFCC[0]
+			}
+			features[i].MFCC = mfcc
+		}
+	}
+
 	return features, nil
 }
 
-// processSequential - Sequential tarzda xususiyatlarni hisoblash
-func (p *Processor) processSequential(frames [][]float32) []FrameFeatures {
-	features := make([]FrameFeatures, len(frames))
-	for i, frame := range frames {
-		features[i] = p.computeFrameFeatures(frame)
+// ProcessOutput - Audio signaldan MFCC o‘rniga spektral matritsani (log-Mel, Mel yoki spektrogramma) hisoblash.
+// Ramkalash, pre-emphasis va oyna funksiyasi MFCC bilan bir xil.
+func (p *Processor) ProcessOutput(audio []float32, kind OutputKind) ([][]float32, error) {
+	if err := kind.validate(); err != nil {
+		return nil, err
+	}
+	frames, err := p.prepareFrames(audio)
+	if err != nil {
+		return nil, err
+	}
+
+	out := make([][]float32, len(frames))
+	err = p.forEachChunk(context.Background(), len(frames), func(start, end int) error {
+		res, err := p.computeChunk(frames[start:end])
+		if err != nil {
+			return err
+		}
+		for i := range res.MFCC {
+			out[start+i] = selectOutput(res, i, kind, p.config.LogMode)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s matritsasini hisoblashda xatolik: %w", kind, err)
+	}
+	if kind == OutputLogMel && p.topDBActive() {
+		clampTopDB(out, p.config.TopDB)
+	}
+	return out, nil
+}
+
+// prepareFrames - Pre-emphasis qo‘llab, signalni ramkalarga bo‘lish
+func (p *Processor) prepareFrames(audio []float32) ([][]float32, error) {
+	if len(audio) == 0 {
+		return nil, errors.New("audio kirishi bo‘sh")
+	}
+
+	// Namunalar shkalasini o‘zgartirish (masalan, Kaldi int16 diapazoni)
+	audio = p.scaleSamples(audio)
+	// Pre-emphasis qo‘llash (FramePreEmphasis bo‘lsa har bir ramkada alohida qo‘llanadi)
+	if p.signalPreEmphasis() {
+		audio = p.applyPreEmphasis(audio)
 	}
-	return features
+	// Signalni ramkalarga bo‘lish
+	return p.frameSignal(audio)
+}
+
+// signalPreEmphasis - Pre-emphasis ramkalashdan oldin butun signalga qo‘llanishini tekshirish
+func (p *Processor) signalPreEmphasis() bool {
+	return !p.config.FramePreEmphasis && p.config.PreEmphasis != 0
 }
 
-// processParallel - Parallel tarzda xususiyatlarni hisoblash
-func (p *Processor) processParallel(frames [][]float32) []FrameFeatures {
-	numFrames := len(frames)
-	features := make([]FrameFeatures, numFrames)
+// chunkFrames - Umumiy navbatdagi bitta ishning ramkalar soni; bekor qilinadigan kontekstda ctx shu oraliqda tekshiriladi
+const chunkFrames = 256
 
-	numWorkers := runtime.NumCPU()
-	if numWorkers > p.config.MaxConcurrency {
-		numWorkers = p.config.MaxConcurrency
+// forEachChunk - Ramkalarni chunkFrames lik ishlarga bo‘lib, Parallel sozlamasiga qarab ketma-ket yoki
+// protsessorning umumiy ishchilar byudjeti (scheduler) orqali parallel bajarish.
+func (p *Processor) forEachChunk(ctx context.Context, numFrames int, fn func(start, end int) error) error {
+	if !p.config.Parallel && ctx.Done() == nil {
+		// Butun signal bitta bo‘lakda: GPU kabi backend’lar katta partiyada samaraliroq
+		return fn(0, numFrames)
+	}
+
+	numJobs := (numFrames + chunkFrames - 1) / chunkFrames
+	job := func(i int) error {
+		start := i * chunkFrames
+		return fn(start, min(start+chunkFrames, numFrames))
+	}
+	if p.config.Parallel {
+		return p.sched.run(ctx, numJobs, job)
 	}
-	chunkSize := (numFrames + numWorkers - 1) / numWorkers
+	for i := 0; i < numJobs; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if err := job(i); err != nil {
+			return err
+		}
+	}
+	return nil
+}
 
-	var wg sync.WaitGroup
-	wg.Add(numWorkers)
+// RunJobs - 0..n-1 ishlarni protsessorning umumiy ishchilar byudjeti doirasida bajarish (masalan, partiya elementlari).
+// Ishlar bo‘sh ishchini navbatdan oladi, shuning uchun uzunligi har xil audiolar ishchilar orasida teng taqsimlanadi;
+// ish ichidagi Process chaqiruvlari ham shu byudjetdan foydalanadi. Birinchi xatolikdan keyin yangi ishlar boshlanmaydi.
+func (p *Processor) RunJobs(ctx context.Context, n int, job func(i int) error) error {
+	return p.sched.run(ctx, n, job)
+}
 
-	for i := 0; i < numWorkers; i++ {
-		start := i * chunkSize
-		end := start + chunkSize
-		go func(start, end int) {
-			defer wg.Done()
-			for j := start; j < end && j < numFrames; j++ {
-				features[j] = p.computeFrameFeatures(frames[j])
+// topDBActive - top_db kesish yoqilganligini tekshirish
+func (p *Processor) topDBActive() bool {
+	return p.config.LogMode == LogDB && p.config.TopDB > 0
+}
+
+// cepstrum - Log-Mel energiyalaridan DCT va lifter orqali MFCC hisoblash
+func (p *Processor) cepstrum(logMel []float32) []float32 {
+	mfcc := p.dct.Apply(logMel, make([]float32, p.config.NumCoefficients))
+	applyLifter(mfcc, p.lifter)
+	return mfcc
+}
+
+// processChunk - Ramkalar bo‘lagi uchun want dagi xususiyatlarni hisoblash. Backend faqat MFCC yoki spektral
+// xususiyatlar so‘ralganda chaqiriladi; ZCR, energiya va pitch to‘g‘ridan-to‘g‘ri ramkadan hisoblanadi.
+// logMel nil bo‘lmasa, unga har bir ramkaning log-Mel energiyalari yoziladi; pitch nil bo‘lmasa,
+// unga Viterbi uchun ramkalar pitch baholari yoziladi (features dagi pitch ramka bo‘yicha alohida tanlanadi).
+// pitchFrames - pre-emphasis qo‘llanmagan ramkalar (nil bo‘lsa pitch frames dan aniqlanadi).
+func (p *Processor) processChunk(frames [][]float32, features []FrameFeatures, want FeatureSet, logMel [][]float32, pitch []pitchEstimate, pitchFrames [][]float32) error {
+	var res *BackendResult
+	if want.spectral() || logMel != nil {
+		var err error
+		if res, err = p.computeChunk(frames); err != nil {
+			return err
+		}
+	} else {
+		p.padFrames(frames)
+	}
+	for i := range logMel {
+		logMel[i] = selectOutput(res, i, OutputLogMel, p.config.LogMode)
+	}
+
+	sampleRate := float32(p.config.SampleRate)
+	var pitchBuf *pitchBuffer
+	if want.Has(FeaturePitch) && p.pitch != nil {
+		pitchBuf = p.pitch.newBuffer()
+		if pitchFrames == nil {
+			pitchFrames = frames
+		} else {
+			p.padFrames(pitchFrames)
+		}
+	}
+	for i, frame := range frames {
+		// Faqat so‘ralgan xususiyatlarni hisoblash
+		var f FrameFeatures
+		if want.Has(FeatureMFCC) {
+			f.MFCC = res.MFCC[i]
+		}
+		if want.Has(FeatureZCR) {
+			f.ZCR = computeZCR(frame)
+		}
+		if want.Has(FeatureSpectralCentroid) {
+			f.SpectralCentroid = computeSpectralCentroid(res.PowerSpectra[i], sampleRate)
+		}
+		if want.Has(FeatureSpectralRollOff) {
+			f.SpectralRollOff = computeSpectralRollOff(res.PowerSpectra[i], sampleRate, 0.85)
+		}
+		if want.Has(FeatureEnergy) {
+			f.Energy = computeEnergy(frame)
+		}
+		if pitchBuf != nil {
+			est := p.pitch.analyze(pitchFrames[i], pitchBuf)
+			f.Pitch, f.Voiced, f.VoicedProb = est.pitch, est.voiced, est.voicedProb
+			if pitch != nil {
+				pitch[i] = est
 			}
-		}(start, end)
+		}
+		features[i] = f
 	}
+	return nil
+}
 
-	wg.Wait()
-	return features
+// computeChunk - Ramkalarga oyna funksiyasini qo‘llab, ularni backend’da hisoblash.
+// UseEnergy yoqilgan bo‘lsa, MFCC ning C0 koeffitsienti ramkaning log energiyasi bilan almashtiriladi.
+func (p *Processor) computeChunk(frames [][]float32) (*BackendResult, error) {
+	p.padFrames(frames)
+	windowed := make([][]float32, len(frames))
+	logEnergy := make([]float32, len(frames))
+	for i := range frames {
+		// FFTSize > FrameLength bo‘lsa, ramka oxiri nollar bilan qoladi
+		windowed[i] = make([]float32, p.config.fftLength())
+		logEnergy[i] = p.windowFrame(frames[i], windowed[i][:p.config.FrameLength])
+	}
+
+	res, err := p.computeBackend(windowed)
+	if err != nil {
+		return nil, err
+	}
+	if p.config.UseEnergy {
+		for i, mfcc := range res.MFCC {
+			if len(mfcc) > 0 {
+				mfcc[0] = logEnergy[i]
+			}
+		}
+	}
+	return res, nil
 }
 
-// computeFrameFeatures - Bitta ramka uchun barcha xususiyatlarni hisoblash
-func (p *Processor) computeFrameFeatures(frame []float32) FrameFeatures {
-	// Ramka uzunligini tekshirish va to‘ldirish
-	if len(frame) != p.config.FrameLength {
-		frame = padFrame(frame, p.config.FrameLength)
+// padFrames - FrameLength dan qisqa ramkalarni joyida nollar bilan to‘ldirish
+func (p *Processor) padFrames(frames [][]float32) {
+	for i, frame := range frames {
+		if len(frame) != p.config.FrameLength {
+			frames[i] = padFrame(frame, p.config.FrameLength)
+		}
 	}
+}
 
-	// Xotira havzasidan buferlarni olish
-	frameBuf := p.memPool.GetFrameBuffer()
-	melBuf := p.memPool.GetMelBuffer()
-	logBuf := p.memPool.GetLogBuffer()
-	dctBuf := p.memPool.GetDCTBuffer()
-	defer p.memPool.PutFrameBuffer(frameBuf)
-	defer p.memPool.PutMelBuffer(melBuf)
-	defer p.memPool.PutLogBuffer(logBuf)
-	defer p.memPool.PutDCTBuffer(dctBuf)
+// windowFrame - Ramkani Kaldi `ProcessWindow` tartibida tayyorlash: dither, DC ni olib tashlash,
+// pre-emphasis (FramePreEmphasis bo‘lsa) va oyna. UseEnergy yoqilgan bo‘lsa log energiyani qaytaradi.
+func (p *Processor) windowFrame(frame, out []float32) float32 {
+	copy(out, frame)
+	if p.config.Dither > 0 {
+		for i := range out {
+			out[i] += p.config.Dither * float32(rand.NormFloat64())
+		}
+	}
+	if p.config.RemoveDCOffset {
+		var sum float64
+		for _, v := range out {
+			sum += float64(v)
+		}
+		mean := float32(sum / float64(len(out)))
+		for i := range out {
+			out[i] -= mean
+		}
+	}
+
+	var logEnergy float32
+	if p.config.UseEnergy && p.config.RawEnergy {
+		logEnergy = p.logEnergy(out)
+	}
+	if p.config.FramePreEmphasis && p.config.PreEmphasis != 0 {
+		coeff := p.config.PreEmphasis
+		for i := len(out) - 1; i > 0; i-- {
+			out[i] -= coeff * out[i-1]
+		}
+		out[0] -= coeff * out[0]
+	}
+	applyWindow(out, p.window, out)
+	if p.config.UseEnergy && !p.config.RawEnergy {
+		logEnergy = p.logEnergy(out)
+	}
+	return logEnergy
+}
+
+// logEnergy - Ramkaning log energiyasi: ln(max(Σx², FLT_EPSILON)), EnergyFloor bilan cheklangan
+func (p *Processor) logEnergy(frame []float32) float32 {
+	var energy float64
+	for _, v := range frame {
+		energy += float64(v) * float64(v)
+	}
+	logEnergy := math.Log(math.Max(energy, kaldiEpsilon))
+	if p.config.EnergyFloor > 0 {
+		logEnergy = math.Max(logEnergy, math.Log(float64(p.config.EnergyFloor)))
+	}
+	return float32(logEnergy)
+}
 
-	// Oyna funksiyasini qo‘llash
-	applyWindow(frame, p.window, frameBuf)
-	// Power spectrumini hisoblash
-	powerSpectrum := computePowerSpectrum(frameBuf)
-	// Mel energiyalarini hisoblash
-	melEnergies := applyMelFilters(powerSpectrum, p.filterBanks, melBuf)
-	// Logarifmik shkalaga o‘tkazish
-	logMelEnergies := applyLog(melEnergies, logBuf)
-	// DCT ni qo‘llash va MFCC chiqarish
-	mfcc := applyDCT(logMelEnergies, p.config.NumCoefficients, dctBuf)
+// computeBackend - Ramkalarni backend’da hisoblash, kerak bo‘lsa zaxira backend’ga o‘tish
+func (p *Processor) computeBackend(windowed [][]float32) (*BackendResult, error) {
+	res, err := runBackend(p.backend, windowed)
+	if err != nil && p.fallback != nil {
+		res, err = runBackend(p.fallback, windowed)
+	}
+	return res, err
+}
 
-	// Barcha qo‘shimcha xususiyatlarni hisoblash
-	return FrameFeatures{
-		MFCC:             mfcc,
-		ZCR:              computeZCR(frame),
-		Pitch:            computePitch(frame, float32(p.config.SampleRate)),
-		SpectralCentroid: computeSpectralCentroid(powerSpectrum, float32(p.config.SampleRate)),
-		SpectralRollOff:  computeSpectralRollOff(powerSpectrum, float32(p.config.SampleRate), 0.85),
-		Energy:           computeEnergy(frame),
+// runBackend - Backend natijasini olish va uning to‘liqligini tekshirish
+func runBackend(b Backend, frames [][]float32) (*BackendResult, error) {
+	res, err := b.Compute(frames)
+	if err != nil {
+		return nil, fmt.Errorf("%s backend’da hisoblashda xatolik: %w", b.Name(), err)
 	}
+	if res == nil || len(res.MFCC) != len(frames) || len(res.PowerSpectra) != len(frames) || len(res.MelEnergies) != len(frames) {
+		return nil, fmt.Errorf("%s backend to‘liq bo‘lmagan natija qaytardi", b.Name())
+	}
+	return res, nil
 }
 
 // Close - Resurslarni ozod qilish
 func (p *Processor) Close() error {
-	if p.gpuCtx != nil {
-		return p.gpuCtx.Cleanup()
+	err := p.backend.Close()
+	if p.fallback != nil {
+		if ferr := p.fallback.Close(); err == nil {
+			err = ferr
+		}
 	}
-	return nil
+	return err
+}
+
+// scaleSamples - Namunalarni SampleScale ga ko‘paytirish (0 yoki 1 bo‘lsa o‘zgarishsiz)
+func (p *Processor) scaleSamples(signal []float32) []float32 {
+	scale := p.config.SampleScale
+	if scale == 0 || scale == 1 {
+		return signal
+	}
+	result := make([]float32, len(signal))
+	for i, v := range signal {
+		result[i] = v * scale
+	}
+	return result
 }
 
 // applyPreEmphasis - Pre-emphasis ni qo‘llash
@@ -207,20 +607,86 @@ func (p *Processor) applyPreEmphasis(signal []float32) []float32 {
 	return result
 }
 
-// frameSignal - Signalni ramkalarga bo‘lish
-func (p *Processor) frameSignal(signal []float32) [][]float32 {
-	numFrames := 1 + (len(signal)-p.config.FrameLength)/p.config.HopLength
-	frames := make([][]float32, numFrames)
+// frameSignal - Signalni Padding sozlamasiga qarab ramkalarga bo‘lish.
+// Hech qanday ramka olinmasa (masalan, to‘ldirishsiz qisqa audio) ErrAudioTooShort qaytariladi.
+func (p *Processor) frameSignal(signal []float32) ([][]float32, error) {
+	frameLength, hopLength := p.config.FrameLength, p.config.HopLength
+	numSamples := len(signal)
+	numFrames, first := p.frameLayout(numSamples)
+	if numFrames <= 0 {
+		return nil, fmt.Errorf("%w: %d namuna, ramka uzunligi %d", ErrAudioTooShort, numSamples, frameLength)
+	}
+
+	// Center va Kaldi rejimlarida chetdan chiqqan namunalar aks bilan olinadi.
+	// PaddingTail da oxirgi ramkaning yetishmagan namunalari computeChunk da nollar bilan to‘ldiriladi.
+	if pad, ok := p.edgePadMode(); ok {
+		left := max(0, -first)
+		right := max(0, first+(numFrames-1)*hopLength+frameLength-numSamples)
+		signal = padSignal(signal, left, right, pad)
+		first += left
+	}
 
+	frames := make([][]float32, numFrames)
 	for i := 0; i < numFrames; i++ {
-		start := i * p.config.HopLength
-		end := start + p.config.FrameLength
-		if end > len(signal) {
-			end = len(signal)
-		}
+		start := first + i*hopLength
+		end := min(start+frameLength, len(signal))
 		frames[i] = signal[start:end]
 	}
-	return frames
+	return frames, nil
+}
+
+// frameLayout - numSamples uzunlikdagi signal uchun ramkalar soni va birinchi ramkaning asl signaldagi
+// boshlanishi (to‘ldirish hisobiga manfiy bo‘lishi mumkin). t-ramka first + t*HopLength dan boshlanadi.
+func (p *Processor) frameLayout(numSamples int) (numFrames, first int) {
+	frameLength, hopLength := p.config.FrameLength, p.config.HopLength
+	switch p.config.Padding {
+	case PaddingKaldi:
+		// Kaldi `snip_edges=false`: ramka markazlari (t+0.5)·HopLength da
+		return kaldiFrameLayout(numSamples, frameLength, hopLength)
+	case PaddingTail:
+		// Oxirgi to‘liq bo‘lmagan ramka ham olinadi
+		if numSamples == 0 {
+			return 0, 0
+		}
+		numFrames = 1
+		if extra := numSamples - frameLength; extra > 0 {
+			numFrames += (extra + hopLength - 1) / hopLength
+		}
+		return numFrames, 0
+	case PaddingCenter:
+		// Ramka markazlari t*HopLength namunaga to‘g‘ri kelishi uchun ikki tomondan FrameLength/2 to‘ldirish
+		first = -(frameLength / 2)
+	}
+	if padded := numSamples - 2*first; padded >= frameLength {
+		numFrames = 1 + (padded-frameLength)/hopLength
+	}
+	return numFrames, first
+}
+
+// edgePadMode - Signal chegarasidan tashqaridagi namunalarni to‘ldirish usuli (Center va Kaldi rejimlarida)
+func (p *Processor) edgePadMode() (PadMode, bool) {
+	switch p.config.Padding {
+	case PaddingKaldi:
+		return PadSymmetric, true
+	case PaddingCenter:
+		return p.config.PadMode, true
+	}
+	return "", false
+}
+
+// FrameTimes - Birinchi numFrames ta ramka markazining asl signaldagi vaqti (soniya).
+// Padding sozlamasi hisobga olinadi, shuning uchun center rejimida t-ramka t*HopLength/SampleRate ga to‘g‘ri keladi.
+func (p *Processor) FrameTimes(numFrames int) []float64 {
+	frameLength, hopLength := p.config.FrameLength, p.config.HopLength
+	// Birinchi ramka boshlanishining asl signalga nisbatan siljishi
+	_, offset := p.frameLayout(0)
+
+	times := make([]float64, numFrames)
+	for i := range times {
+		center := offset + i*hopLength + frameLength/2
+		times[i] = float64(center) / float64(p.config.SampleRate)
+	}
+	return times
 }
 
 // padFrame - Ramkani kerakli uzunlikka to‘ldirish
@@ -236,11 +702,3 @@ func padFrame(frame []float32, length int) []float32 {
 	}
 	return padded
 }
-
-// computeFrameMFCC - Bitta ramka uchun faqat MFCC koeffitsientlarini hisoblash
-func (p *Processor) computeFrameMFCC(frame []float32) []float32 {
-	// computeFrameFeatures ni chaqirib, to‘liq xususiyatlarni hisoblaymiz
-	features := p.computeFrameFeatures(frame)
-	// Faqat MFCC koeffitsientlarini qaytaramiz
-	return features.MFCC
-}
@@ -0,0 +1,238 @@
+package internal
+
+import (
+	"fmt"
+	"math"
+)
+
+// ResampleQuality - Namunalar tezligini o‘zgartirish sifati (Kaiser oynali sinc filtr parametrlari)
+type ResampleQuality string
+
+const (
+	ResampleFast   ResampleQuality = "fast"   // 16 nol kesishish, tez (resampy `kaiser_fast`)
+	ResampleMedium ResampleQuality = "medium" // 32 nol kesishish
+	ResampleHigh   ResampleQuality = "high"   // 64 nol kesishish, standart (resampy `kaiser_best`)
+)
+
+// resampleParams - Sifat darajasi uchun filtr parametrlari
+type resampleParams struct {
+	zeros   int     // Sinc yadrosining bir tomondagi nol kesishishlar soni
+	rolloff float64 // O‘tkazish polosasi chegarasi (Nyquist ga nisbatan)
+	beta    float64 // Kaiser oynasi parametri
+}
+
+var resampleQualities = map[ResampleQuality]resampleParams{
+	ResampleFast:   {zeros: 16, rolloff: 0.85, beta: 8.555},
+	ResampleMedium: {zeros: 32, rolloff: 0.91, beta: 11.5},
+	ResampleHigh:   {zeros: 64, rolloff: 0.9475937167399596, beta: 14.769656459379492},
+}
+
+// maxCachedPhases - Shundan ko‘p fazali nisbatlar uchun filtr koeffitsientlari keshlanmaydi (xotirani tejash)
+const maxCachedPhases = 4096
+
+// validateResampleQuality - Sifat darajasini tekshirish
+func validateResampleQuality(quality ResampleQuality) error {
+	if _, ok := resampleQualities[quality]; !ok && quality != "" {
+		return fmt.Errorf("unknown resample quality %q", quality)
+	}
+	return nil
+}
+
+// Resample - Signalni fromRate dan toRate ga polifazali Kaiser oynali sinc filtr bilan o‘tkazish.
+// Nisbat L/M (gcd bo‘yicha qisqartirilgan) ko‘rinishida aniq hisoblanadi; pastga o‘tkazishda filtr
+// kesish chastotasi yangi Nyquist ga tushiriladi (aliasingga qarshi). Signal chegarasidan tashqari nol deb olinadi.
+func Resample(signal []float32, fromRate, toRate int, quality ResampleQuality) ([]float32, error) {
+	if fromRate <= 0 || toRate <= 0 {
+		return nil, fmt.Errorf("namunalar tezligi musbat bo‘lishi kerak: %d -> %d", fromRate, toRate)
+	}
+	if err := validateResampleQuality(quality); err != nil {
+		return nil, err
+	}
+	if fromRate == toRate {
+		return append([]float32(nil), signal...), nil
+	}
+	if quality == "" {
+		quality = ResampleHigh
+	}
+	return newPolyphaseFilter(fromRate, toRate, resampleQualities[quality]).apply(signal), nil
+}
+
+// polyphaseFilter - L/M nisbat uchun polifazali filtr
+type polyphaseFilter struct {
+	up, down int     // L (interpolatsiya) va M (decimatsiya)
+	cutoff   float64 // Kesish chastotasi (kirish Nyquist ga nisbatan)
+	half     int     // Filtrning bir tomondagi tap soni
+	params   resampleParams
+	phases   [][]float32 // Har bir faza uchun 2*half ta koeffitsient (lazy to‘ldiriladi)
+	scratch  []float32   // Keshlanmaydigan fazalar uchun vaqtinchalik bufer
+}
+
+// newPolyphaseFilter - Yangi polifazali filtr yaratish
+func newPolyphaseFilter(fromRate, toRate int, params resampleParams) *polyphaseFilter {
+	g := gcd(fromRate, toRate)
+	up, down := toRate/g, fromRate/g
+	cutoff := params.rolloff * math.Min(1, float64(up)/float64(down))
+	f := &polyphaseFilter{
+		up:     up,
+		down:   down,
+		cutoff: cutoff,
+		half:   int(math.Ceil(float64(params.zeros) / cutoff)),
+		params: params,
+	}
+	if up <= maxCachedPhases {
+		f.phases = make([][]float32, up)
+	} else {
+		f.scratch = make([]float32, 2*f.half)
+	}
+	return f
+}
+
+// phase - p-faza koeffitsientlari: t = base + p/L nuqtadagi chiqish uchun x[base-half+1+k] og‘irliklari
+func (f *polyphaseFilter) phase(p int) []float32 {
+	if f.phases != nil && f.phases[p] != nil {
+		return f.phases[p]
+	}
+	taps := f.scratch
+	if f.phases != nil {
+		taps = make([]float32, 2*f.half)
+		f.phases[p] = taps
+	}
+
+	frac := float64(p) / float64(f.up)
+	width := float64(f.params.zeros) / f.cutoff // Oyna yarim kengligi (kirish namunalarida)
+	norm := besselI0(f.params.beta)
+	for k := range taps {
+		d := frac + float64(f.half-1-k) // t - j
+		u := d / width
+		if u <= -1 || u >= 1 {
+			taps[k] = 0
+			continue
+		}
+		window := besselI0(f.params.beta*math.Sqrt(1-u*u)) / norm
+		taps[k] = float32(f.cutoff * sinc(f.cutoff*d) * window)
+	}
+	return taps
+}
+
+// apply - Signalni filtr orqali o‘tkazish
+func (f *polyphaseFilter) apply(signal []float32) []float32 {
+	n := len(signal)
+	out := make([]float32, f.outputLength(n))
+	for i := range out {
+		out[i] = f.sample(signal, 0, n, i)
+	}
+	return out
+}
+
+// outputLength - n ta kirish namunasidan olinadigan chiqish namunalari soni
+func (f *polyphaseFilter) outputLength(n int) int {
+	return (n*f.up + f.down - 1) / f.down
+}
+
+// sample - i-chiqish namunasini hisoblash. signal[0] kirishning offset-namunasiga mos keladi;
+// 0 dan oldingi va n dan keyingi namunalar nol deb olinadi.
+func (f *polyphaseFilter) sample(signal []float32, offset, n, i int) float32 {
+	pos := i * f.down
+	base, p := pos/f.up, pos%f.up
+	taps := f.phase(p)
+
+	first := base - f.half + 1
+	lo, hi := max(0, -first), min(len(taps), n-first)
+	var acc float64
+	for k := lo; k < hi; k++ {
+		acc += float64(taps[k]) * float64(signal[first+k-offset])
+	}
+	return float32(acc)
+}
+
+// streamResampler - Signalni bo‘laklab qayta namunalash. Natija butun signalga Resample qo‘llangani bilan
+// bir xil; xotirada faqat filtr uzunligidagi oxirgi kirish namunalari saqlanadi.
+type streamResampler struct {
+	filter  *polyphaseFilter
+	history []float32 // Hali kerak bo‘ladigan kirish namunalari
+	start   int       // history[0] ning absolyut indeksi
+	total   int       // Jami qabul qilingan kirish namunalari
+	next    int       // Hisoblanadigan keyingi chiqish namunasi indeksi
+}
+
+// newStreamResampler - fromRate dan toRate ga oqimli qayta namunalagich yaratish
+func newStreamResampler(fromRate, toRate int, quality ResampleQuality) (*streamResampler, error) {
+	if fromRate <= 0 || toRate <= 0 {
+		return nil, fmt.Errorf("namunalar tezligi musbat bo‘lishi kerak: %d -> %d", fromRate, toRate)
+	}
+	if err := validateResampleQuality(quality); err != nil {
+		return nil, err
+	}
+	if quality == "" {
+		quality = ResampleHigh
+	}
+	return &streamResampler{filter: newPolyphaseFilter(fromRate, toRate, resampleQualities[quality])}, nil
+}
+
+// write - Kirish namunalarini qo‘shib, barcha kerakli kirishlari kelgan chiqish namunalarini qaytarish
+func (r *streamResampler) write(in []float32) []float32 {
+	f := r.filter
+	r.history = append(r.history, in...)
+	r.total += len(in)
+
+	var out []float32
+	for {
+		// Filtrning oxirgi tapi base+half namunaga tushadi
+		if base := r.next * f.down / f.up; base+f.half >= r.total {
+			break
+		}
+		out = append(out, f.sample(r.history, r.start, r.total, r.next))
+		r.next++
+	}
+
+	// Keyingi chiqish uchun kerak bo‘lmaydigan namunalarni tashlash
+	first := r.next*f.down/f.up - f.half + 1
+	if drop := min(first-r.start, len(r.history)); drop > 0 {
+		r.history = append(r.history[:0], r.history[drop:]...)
+		r.start += drop
+	}
+	return out
+}
+
+// flush - Signal tugaganda qolgan chiqish namunalarini (oxiridan keyingi namunalar nol deb) qaytarish
+func (r *streamResampler) flush() []float32 {
+	f := r.filter
+	var out []float32
+	for end := f.outputLength(r.total); r.next < end; r.next++ {
+		out = append(out, f.sample(r.history, r.start, r.total, r.next))
+	}
+	r.history = nil
+	return out
+}
+
+// Resample - Audio signalni konfiguratsiyadagi SampleRate ga ResampleQuality sifatida o‘tkazish
+func (p *Processor) Resample(audio []float32, fromRate int) ([]float32, error) {
+	return Resample(audio, fromRate, p.config.SampleRate, p.config.ResampleQuality)
+}
+
+// sinc - Normallashgan sinc funksiyasi: sin(πx)/(πx)
+func sinc(x float64) float64 {
+	if x == 0 {
+		return 1
+	}
+	return math.Sin(math.Pi*x) / (math.Pi * x)
+}
+
+// besselI0 - Birinchi turdagi nolinchi tartibli modifikatsiyalangan Bessel funksiyasi (qator yig‘indisi)
+func besselI0(x float64) float64 {
+	sum, term := 1.0, 1.0
+	q := x * x / 4
+	for k := 1; term > sum*1e-17; k++ {
+		term *= q / float64(k*k)
+		sum += term
+	}
+	return sum
+}
+
+// gcd - Eng katta umumiy bo‘luvchi
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+	return a
+}
@@ -0,0 +1,76 @@
+package internal
+
+import (
+	"context"
+	"runtime"
+	"sync"
+	"sync/atomic"
+)
+
+// scheduler - Protsessor bo‘yicha umumiy parallellik byudjeti va ishlar navbati.
+// Ichma-ich chaqiruvlar (partiya ichidagi har bir audio ramkalari) ham shu byudjetdan foydalanadi,
+// shuning uchun goroutine’lar soni hech qachon byudjetdan oshmaydi.
+type scheduler struct {
+	tokens chan struct{} // Bo‘sh yordamchi o‘rinlari (byudjet - 1, chaqiruvchi goroutine o‘zi ham ishlaydi)
+}
+
+// newScheduler - workers ta parallel ishchi byudjeti bilan rejalashtiruvchi yaratish
+func newScheduler(workers int) *scheduler {
+	workers = max(1, min(workers, runtime.GOMAXPROCS(0)))
+	tokens := make(chan struct{}, workers-1)
+	for i := 0; i < workers-1; i++ {
+		tokens <- struct{}{}
+	}
+	return &scheduler{tokens: tokens}
+}
+
+// run - 0..n-1 ishlarni umumiy navbatdan bajarish. Chaqiruvchi goroutine doim ishlaydi; ish olganda bo‘sh
+// o‘rin bo‘lsa yordamchi goroutine qo‘shiladi. Shuning uchun uzun ishlar qisqalaridan keyin qolsa, bo‘shagan
+// o‘rinlar ichma-ich chaqiruvlarga o‘tadi. Birinchi xatolik (yoki ctx.Err()) dan keyin yangi ishlar olinmaydi.
+func (s *scheduler) run(ctx context.Context, n int, job func(i int) error) error {
+	var (
+		next     atomic.Int64
+		failed   atomic.Bool
+		errOnce  sync.Once
+		firstErr error
+		wg       sync.WaitGroup
+	)
+	var work func()
+	// grow - Navbatda ish qolgan va bo‘sh o‘rin bo‘lsa yordamchi qo‘shish
+	grow := func() {
+		if next.Load() >= int64(n) {
+			return
+		}
+		select {
+		case <-s.tokens:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				defer func() { s.tokens <- struct{}{} }()
+				work()
+			}()
+		default:
+		}
+	}
+	work = func() {
+		for !failed.Load() {
+			i := int(next.Add(1) - 1)
+			if i >= n {
+				return
+			}
+			grow()
+			err := ctx.Err()
+			if err == nil {
+				err = job(i)
+			}
+			if err != nil {
+				errOnce.Do(func() { firstErr = err })
+				failed.Store(true)
+			}
+		}
+	}
+
+	work()
+	wg.Wait()
+	return firstErr
+}
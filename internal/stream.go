@@ -1,37 +1,84 @@
 package internal
 
 import (
+	"context"
+	"errors"
 	"sync"
 )
 
-// Streamer - Streaming uchun ma’lumotlarni qayta ishlaydi
+// errStreamerClosed - Streamer yopilgandan keyin natija yuborishga urinish
+var errStreamerClosed = errors.New("streamer yopilgan")
+
+// Streamer - Real vaqtda yozilayotgan namunalardan MFCC vektorlarini hisoblaydi.
+// Ramkalash, pre-emphasis holati, Padding, CMVN va deltalar FrameExtractor orqali bajariladi, shuning uchun
+// Flush gacha yozilgan signal uchun natija Process bilan bir xil. Vektorlar natija kanaliga bloklanib yoziladi:
+// o‘quvchi sekin bo‘lsa hisoblash kutadi, ramkalar tashlab yuborilmaydi.
 type Streamer struct {
-	proc        *Processor
-	buffer      []float32
-	bufferMutex sync.Mutex
-	resultChan  chan []float32
-	closeChan   chan struct{}
-	wg          sync.WaitGroup
+	proc       *Processor
+	extractor  *FrameExtractor // Joriy oqim ekstraktori (faqat processLoop ishlatadi)
+	mu         sync.Mutex
+	pending    [][]float32 // processLoop hali olmagan bo‘laklar; nil bo‘lak Flush ni bildiradi
+	closed     bool
+	err        error          // Birinchi hisoblash xatoligi
+	wake       chan struct{}  // processLoop ga yangi bo‘lak haqida xabar
+	resultChan chan []float32 // Natija kanali (hajmi MaxConcurrency)
+	closeChan  chan struct{}
+	ctx        context.Context // Bekor qilinganda processLoop to‘xtaydi va Read nil qaytaradi
+	wg         sync.WaitGroup
 }
 
 // NewStreamer - Yangi streamer yaratish
-func (p *Processor) NewStreamer() *Streamer {
+func (p *Processor) NewStreamer() (*Streamer, error) {
+	return p.NewStreamerContext(context.Background())
+}
+
+// NewStreamerContext - ctx ga bog‘langan streamer yaratish: ctx bekor qilinganda fon goroutine to‘xtaydi.
+// Oqimda ishlatib bo‘lmaydigan sozlamalar uchun NewFrameExtractor xatoligi qaytariladi.
+func (p *Processor) NewStreamerContext(ctx context.Context) (*Streamer, error) {
 	s := &Streamer{
+		ctx:        ctx,
 		proc:       p,
-		buffer:     make([]float32, 0, p.config.FrameLength*4),    // Boshlang‘ich hajmni optimallashtirish
+		wake:       make(chan struct{}, 1),
 		resultChan: make(chan []float32, p.config.MaxConcurrency), // Buffer hajmini maxConcurrency ga moslashtirish
 		closeChan:  make(chan struct{}),
 	}
+	if err := s.reset(); err != nil {
+		return nil, err
+	}
 	s.wg.Add(1)
 	go s.processLoop()
-	return s
+	return s, nil
 }
 
-// Write - Ma’lumotlarni buferga yozish
+// Write - Namunalar nusxasini navbatga qo‘shish; hisoblash fon goroutine’da bajariladi.
+// Close dan keyin chaqirilsa hech narsa qilmaydi.
 func (s *Streamer) Write(data []float32) {
-	s.bufferMutex.Lock()
-	defer s.bufferMutex.Unlock()
-	s.buffer = append(s.buffer, data...)
+	if len(data) == 0 {
+		return
+	}
+	s.enqueue(append([]float32(nil), data...))
+}
+
+// Flush - Joriy oqimni yakunlash: oxirgi ramkalar (Padding bo‘yicha o‘ng chetdagi to‘ldirish bilan) va
+// delta buferida qolgan ramkalar chiqariladi. Keyingi Write lar yangi oqim sifatida boshlanadi.
+// Close dan keyin chaqirilsa hech narsa qilmaydi.
+func (s *Streamer) Flush() {
+	s.enqueue(nil)
+}
+
+// enqueue - Bo‘lakni navbatga qo‘shib, processLoop ni uyg‘otish
+func (s *Streamer) enqueue(chunk []float32) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.closed {
+		return
+	}
+	s.pending = append(s.pending, chunk)
+	select {
+	case s.wake <- struct{}{}:
+	default:
+		// processLoop allaqachon uyg‘otilgan
+	}
 }
 
 // Read - Natijani olish
@@ -41,17 +88,38 @@ func (s *Streamer) Read() []float32 {
 		return mfcc
 	case <-s.closeChan:
 		return nil
+	case <-s.ctx.Done():
+		return nil
 	}
 }
 
-// Close - Streamer’ni to‘xtatish
+// Err - Kontekst bekor qilingan bo‘lsa uning xatoligini, aks holda birinchi hisoblash xatoligini
+// (masalan, Flush dagi ErrAudioTooShort) qaytarish
+func (s *Streamer) Err() error {
+	if err := s.ctx.Err(); err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.err
+}
+
+// Close - Streamer’ni to‘xtatish; takroriy chaqiruvlar hech narsa qilmaydi
 func (s *Streamer) Close() {
+	s.mu.Lock()
+	if s.closed {
+		s.mu.Unlock()
+		return
+	}
+	s.closed = true
+	s.mu.Unlock()
+
 	close(s.closeChan)
 	s.wg.Wait()
 	close(s.resultChan)
 }
 
-// processLoop - Doimiy ravishda mavjud ramkalarni qayta ishlaydi
+// processLoop - Navbatdagi bo‘laklarni kelish tartibida ekstraktorga uzatadi
 func (s *Streamer) processLoop() {
 	defer s.wg.Done()
 
@@ -59,27 +127,66 @@ func (s *Streamer) processLoop() {
 		select {
 		case <-s.closeChan:
 			return
-		default:
-			s.processAvailableFrames()
+		case <-s.ctx.Done():
+			return
+		case <-s.wake:
+		}
+
+		s.mu.Lock()
+		chunks := s.pending
+		s.pending = nil
+		s.mu.Unlock()
+
+		for _, chunk := range chunks {
+			err := s.process(chunk)
+			if errors.Is(err, errStreamerClosed) || s.ctx.Err() != nil {
+				return
+			}
+			if err != nil {
+				s.mu.Lock()
+				if s.err == nil {
+					s.err = err
+				}
+				s.mu.Unlock()
+			}
 		}
 	}
 }
 
-// processAvailableFrames - Mavjud ramkalarni qayta ishlaydi
-func (s *Streamer) processAvailableFrames() {
-	s.bufferMutex.Lock()
-	defer s.bufferMutex.Unlock()
+// process - Bo‘lakni ekstraktorga yozish; nil bo‘lakda oqimni yakunlab, yangi ekstraktor ochish
+func (s *Streamer) process(chunk []float32) error {
+	if chunk != nil {
+		return s.extractor.Write(chunk)
+	}
+	var err error
+	if s.extractor.received > 0 {
+		err = s.extractor.Close()
+	}
+	if rerr := s.reset(); err == nil {
+		err = rerr
+	}
+	return err
+}
 
-	cfg := s.proc.config
-	for len(s.buffer) >= cfg.FrameLength {
-		frame := s.buffer[:cfg.FrameLength]
-		s.buffer = s.buffer[cfg.HopLength:]
+// reset - Yangi oqim uchun ekstraktor ochish
+func (s *Streamer) reset() error {
+	e, err := s.proc.NewFrameExtractor(s.proc.config.SampleRate, s.send)
+	if err != nil {
+		return err
+	}
+	s.extractor = e
+	return nil
+}
 
-		mfcc := s.proc.computeFrameMFCC(frame)
-		select {
-		case s.resultChan <- mfcc: // Non-blocking yozish
-		default:
-			// Agar kanal to‘la bo‘lsa, o‘tkazib yuboramiz
-		}
+// send - Tayyor ramka vektorini natija kanaliga yozish; kanal to‘la bo‘lsa o‘quvchini kutadi.
+// Streamer yopilsa yoki kontekst bekor qilinsa xatolik qaytariladi va ekstraktor to‘xtaydi.
+func (s *Streamer) send(f FrameFeatures) error {
+	select {
+	case s.resultChan <- f.Vector():
+		return nil
+	case <-s.closeChan:
+		return errStreamerClosed
+	case <-s.ctx.Done():
+		return s.ctx.Err()
 	}
 }
@@ -1,35 +1,17 @@
 package internal
 
-import (
-	"github.com/mjibson/go-dsp/fft"
-	"math"
-)
+import "math"
 
 // computePowerSpectrum - Power spectrumini hisoblash
-// Bu funksiya audio ramkaning chastota spektri quvvatini hisoblaydi, model o‘qitish uchun asosiy xususiyat
-func computePowerSpectrum(frame []float32) []float32 {
+// Bu funksiya audio ramkaning chastota spektri quvvatini hisoblaydi, model o‘qitish uchun asosiy xususiyat.
+// plan - len(frame) uzunlikdagi FFT rejasi, buf - kamida plan.BufferLen() uzunlikdagi vaqtinchalik bufer
+func computePowerSpectrum(frame []float32, plan *RealFFT, buf []complex64) []float32 {
 	n := len(frame)
 	if n == 0 {
 		return nil
 	}
-
-	// Kompleks signalni tayyorlash
-	complexFrame := make([]complex128, n)
-	for i, v := range frame {
-		complexFrame[i] = complex(float64(v), 0)
-	}
-
-	// FFT ni hisoblash
-	fftResult := fft.FFT(complexFrame)
-
-	// Power spectrumini hisoblash
 	powerSpectrum := make([]float32, n/2+1)
-	for i := range powerSpectrum {
-		re := real(fftResult[i])
-		im := imag(fftResult[i])
-		powerSpectrum[i] = float32(re*re + im*im)
-	}
-
+	plan.PowerSpectrum(frame, powerSpectrum, buf)
 	return powerSpectrum
 }
 
@@ -44,25 +26,6 @@ func applyWindow(frame, window, buffer []float32) {
 	}
 }
 
-// applyMelFilters - Mel filtrlarini qo‘llash
-// Bu funksiya power spectrumga Mel filtrlarini qo‘llab, energiya qiymatlarini hisoblaydi
-func applyMelFilters(powerSpectrum []float32, filterBanks [][]float32, melBuf []float32) []float32 {
-	for i := range melBuf {
-		melBuf[i] = 0
-	}
-
-	for i, filter := range filterBanks {
-		var energy float32
-		for j, val := range filter {
-			if j < len(powerSpectrum) {
-				energy += val * powerSpectrum[j]
-			}
-		}
-		melBuf[i] = energy
-	}
-	return melBuf
-}
-
 // applyLog - Logarifmik shkalaga o‘tkazish
 // Bu funksiya Mel energiyalarini logarifmik shkalaga aylantiradi, MFCC uchun muhim qadam
 func applyLog(values []float32, logBuf []float32) []float32 {
@@ -77,30 +40,126 @@ func applyLog(values []float32, logBuf []float32) []float32 {
 	return logBuf
 }
 
-// applyDCT - Diskret Kosinus Transformatsiyasini qo‘llash
-// Bu funksiya log Mel energiyalarini MFCC koeffitsientlariga aylantiradi
-func applyDCT(logMelEnergies []float32, numCoeffs int, dctBuf []float32) []float32 {
-	n := len(logMelEnergies)
-	if n == 0 || numCoeffs <= 0 {
-		return nil
+// LogMode - Mel energiyalarini logarifmik shkalaga o‘tkazish usuli
+type LogMode string
+
+const (
+	LogNatural LogMode = "natural" // ln(x + 1e-6)
+	LogDB      LogMode = "db"      // 10·log10(max(x, 1e-10)), librosa `power_to_db(ref=1.0)`
+	LogKaldi   LogMode = "kaldi"   // ln(max(x, FLT_EPSILON)), Kaldi `compute-mfcc-feats`
+)
+
+// dbAmin - dB o‘tkazishdagi minimal amplituda (librosa `amin`)
+const dbAmin = 1e-10
+
+// kaldiEpsilon - Kaldi log energiya va Mel energiyalari uchun ishlatadigan pastki chegara (FLT_EPSILON)
+const kaldiEpsilon = 1.1920928955078125e-07
+
+// applyLogMode - Tanlangan usul bo‘yicha logarifmik shkalaga o‘tkazish
+func applyLogMode(values []float32, logBuf []float32, mode LogMode) []float32 {
+	switch mode {
+	case LogDB:
+		for i, v := range values {
+			logBuf[i] = float32(10 * math.Log10(math.Max(float64(v), dbAmin)))
+		}
+	case LogKaldi:
+		for i, v := range values {
+			logBuf[i] = float32(math.Log(math.Max(float64(v), kaldiEpsilon)))
+		}
+	default:
+		return applyLog(values, logBuf)
 	}
+	return logBuf[:len(values)]
+}
 
-	sqrt2OverN := float32(math.Sqrt(2.0 / float64(n)))
+// clampTopDB - dB qiymatlarini butun matritsa maksimumidan topDB pastda kesish (librosa `top_db`)
+func clampTopDB(rows [][]float32, topDB float32) {
+	maxDB := float32(math.Inf(-1))
+	for _, row := range rows {
+		for _, v := range row {
+			if v > maxDB {
+				maxDB = v
+			}
+		}
+	}
+	floor := maxDB - topDB
+	for _, row := range rows {
+		for i, v := range row {
+			if v < floor {
+				row[i] = floor
+			}
+		}
+	}
+}
 
-	for k := 0; k < numCoeffs; k++ {
-		var sum float32
-		for m, val := range logMelEnergies {
-			angle := math.Pi * float64(k) * (float64(m) + 0.5) / float64(n)
-			sum += val * float32(math.Cos(angle))
+// DCTMatrix - Oldindan hisoblangan ortonormal DCT-II bazisi.
+// Log Mel energiyalarini MFCC koeffitsientlariga aylantiradi; kosinuslar har bir ramkada emas, bir marta hisoblanadi.
+type DCTMatrix struct {
+	NumFilters      int       // Kirish uzunligi N (Mel filtrlar soni)
+	NumCoefficients int       // Chiqish uzunligi K
+	Basis           []float32 // cos(πk(m+0.5)/N) qiymatlari, K×N qatorlar bo‘yicha: Basis[k*N+m]
+	Scale           []float32 // Koeffitsient ko‘paytuvchisi: k = 0 uchun √(1/N), qolganlari uchun √(2/N)
+}
+
+// NewDCTMatrix - numFilters kirish va numCoeffs chiqish uchun DCT bazisini yaratish
+func NewDCTMatrix(numFilters, numCoeffs int) *DCTMatrix {
+	d := &DCTMatrix{NumFilters: numFilters, NumCoefficients: max(numCoeffs, 0)}
+	if numFilters <= 0 {
+		return d
+	}
+	d.Basis = make([]float32, d.NumCoefficients*numFilters)
+	d.Scale = make([]float32, d.NumCoefficients)
+	for k := 0; k < d.NumCoefficients; k++ {
+		for m := 0; m < numFilters; m++ {
+			angle := math.Pi * float64(k) * (float64(m) + 0.5) / float64(numFilters)
+			d.Basis[k*numFilters+m] = float32(math.Cos(angle))
 		}
 		if k == 0 {
-			dctBuf[k] = sum * float32(math.Sqrt(1.0/float64(n)))
+			d.Scale[k] = float32(math.Sqrt(1.0 / float64(numFilters)))
 		} else {
-			dctBuf[k] = sum * sqrt2OverN
+			d.Scale[k] = float32(math.Sqrt(2.0 / float64(numFilters)))
 		}
 	}
+	return d
+}
 
-	return dctBuf[:numCoeffs]
+// Apply - logMel (NumFilters uzunlikda) ga DCT ni qo‘llab, koeffitsientlarni out ga yozish
+func (d *DCTMatrix) Apply(logMel, out []float32) []float32 {
+	n := d.NumFilters
+	if n == 0 || d.NumCoefficients == 0 {
+		return nil
+	}
+	logMel = logMel[:n]
+	for k := range out[:d.NumCoefficients] {
+		var sum float32
+		for m, w := range d.Basis[k*n : (k+1)*n] {
+			sum += logMel[m] * w
+		}
+		out[k] = sum * d.Scale[k]
+	}
+	return out[:d.NumCoefficients]
+}
+
+// createLifter - Sinusoidal lifter koeffitsientlarini yaratish (HTK/Kaldi: 1 + (L/2)·sin(πn/L))
+// cepLifter <= 0 bo‘lsa nil qaytaradi
+func createLifter(numCoeffs, cepLifter int) []float32 {
+	if cepLifter <= 0 {
+		return nil
+	}
+	lifter := make([]float32, numCoeffs)
+	for n := range lifter {
+		lifter[n] = float32(1 + 0.5*float64(cepLifter)*math.Sin(math.Pi*float64(n)/float64(cepLifter)))
+	}
+	return lifter
+}
+
+// applyLifter - MFCC koeffitsientlariga lifterni joyida qo‘llash
+func applyLifter(coeffs, lifter []float32) {
+	for n := range coeffs {
+		if n < len(lifter) {
+			coeffs[n] *= lifter[n]
+		}
+	}
 }
 
 // computeSpectralCentroid - Spectral Centroid ni hisoblash
@@ -172,47 +231,3 @@ func computeZCR(frame []float32) float32 {
 	}
 	return zcr / float32(len(frame)-1)
 }
-
-// computePitch - Pitch (fundamental chastota) ni hisoblash
-// Bu funksiya autokorrelyatsiya usuli bilan pitch ni hisoblaydi
-func computePitch(frame []float32, sampleRate float32) float32 {
-	n := len(frame)
-	if n < 2 {
-		return 0
-	}
-
-	// Autokorrelyatsiyani hisoblash
-	autocorr := make([]float32, n)
-	for lag := 0; lag < n; lag++ {
-		var sum float32
-		for i := 0; i < n-lag; i++ {
-			sum += frame[i] * frame[i+lag]
-		}
-		autocorr[lag] = sum
-	}
-
-	// Maksimal lag ni topish
-	minPeriod := int(sampleRate / 400) // 400 Hz - maksimal pitch
-	maxPeriod := int(sampleRate / 50)  // 50 Hz - minimal pitch
-	if maxPeriod >= n {
-		maxPeriod = n - 1
-	}
-	if minPeriod < 1 {
-		minPeriod = 1
-	}
-
-	maxCorr := float32(0)
-	lagAtMax := 0
-	for lag := minPeriod; lag <= maxPeriod; lag++ {
-		if autocorr[lag] > maxCorr {
-			maxCorr = autocorr[lag]
-			lagAtMax = lag
-		}
-	}
-
-	if lagAtMax == 0 {
-		return 0
-	}
-
-	return sampleRate / float32(lagAtMax)
-}
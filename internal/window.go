@@ -5,23 +5,39 @@ import (
 )
 
 // createWindow - Oyna funksiyasini yaratish
+// periodic=true bo‘lsa, oyna N davr bilan hisoblanadi (scipy/librosa `fftbins=True`), aks holda simmetrik (N-1)
 // Xotira optimallashtirish: har safar yangi massiv o‘rniga qayta ishlatish mumkin
-func createWindow(length int, wType WindowType) []float32 {
+func createWindow(length int, wType WindowType, periodic bool) []float32 {
 	window := make([]float32, length)
 
+	denom := float64(length - 1)
+	if periodic {
+		denom = float64(length)
+	}
+	if denom <= 0 { // Bitta namunali oyna
+		for i := range window {
+			window[i] = 1.0
+		}
+		return window
+	}
+
 	switch wType {
 	case Hamming: // Hamming oynasi
 		for i := range window {
-			window[i] = float32(0.54 - 0.46*math.Cos(2*math.Pi*float64(i)/float64(length-1)))
+			window[i] = float32(0.54 - 0.46*math.Cos(2*math.Pi*float64(i)/denom))
 		}
 	case Hanning: // Hanning oynasi
 		for i := range window {
-			window[i] = float32(0.5 * (1 - math.Cos(2*math.Pi*float64(i)/float64(length-1))))
+			window[i] = float32(0.5 * (1 - math.Cos(2*math.Pi*float64(i)/denom)))
 		}
 	case Blackman: // Blackman oynasi
 		for i := range window {
-			window[i] = float32(0.42 - 0.5*math.Cos(2*math.Pi*float64(i)/float64(length-1)) +
-				0.08*math.Cos(4*math.Pi*float64(i)/float64(length-1)))
+			window[i] = float32(0.42 - 0.5*math.Cos(2*math.Pi*float64(i)/denom) +
+				0.08*math.Cos(4*math.Pi*float64(i)/denom))
+		}
+	case Povey: // Povey oynasi (Kaldi): Hanning oynasining 0.85 darajasi
+		for i := range window {
+			window[i] = float32(math.Pow(0.5-0.5*math.Cos(2*math.Pi*float64(i)/denom), 0.85))
 		}
 	case Rect: // To‘rtburchak oynasi
 		for i := range window {
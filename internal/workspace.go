@@ -0,0 +1,56 @@
+package internal
+
+import "sync"
+
+// workspace - Bitta ishchi goroutine’ning ramkalarni hisoblashdagi vaqtinchalik buferlari.
+// Buferlar faqat Compute ichida ishlatiladi; natijalar har doim yangi slice’larga yoziladi,
+// shuning uchun havzaga qaytarilgan workspace hech qachon qaytarilgan natija bilan xotirani bo‘lishmaydi.
+type workspace struct {
+	fftBuf []complex64 // FFT kirishi, spektri va radix buferi
+	logMel []float32   // Log-Mel energiyalari
+}
+
+// fftBuffer - n uzunlikdagi FFT buferi (sig‘im yetmasa qayta yaratiladi)
+func (w *workspace) fftBuffer(n int) []complex64 {
+	if cap(w.fftBuf) < n {
+		w.fftBuf = make([]complex64, n)
+	}
+	return w.fftBuf[:n]
+}
+
+// logBuffer - n uzunlikdagi log-Mel buferi (sig‘im yetmasa qayta yaratiladi)
+func (w *workspace) logBuffer(n int) []float32 {
+	if cap(w.logMel) < n {
+		w.logMel = make([]float32, n)
+	}
+	return w.logMel[:n]
+}
+
+// workspacePool - Konfiguratsiya o‘lchamlaridagi workspace’lar havzasi (sync.Pool ustida).
+// Havza bo‘sh bo‘lsa yangi workspace yaratiladi, shuning uchun parallel chaqiruvlar soni cheklanmagan
+// va hech bir chaqiruv boshqasining buferini olmaydi.
+type workspacePool struct {
+	pool sync.Pool
+}
+
+// newWorkspacePool - fftBufferLen uzunlikdagi FFT buferi va numFilters ta Mel filtr uchun havza yaratish
+func newWorkspacePool(fftBufferLen, numFilters int) *workspacePool {
+	p := &workspacePool{}
+	p.pool.New = func() any {
+		return &workspace{
+			fftBuf: make([]complex64, fftBufferLen),
+			logMel: make([]float32, numFilters),
+		}
+	}
+	return p
+}
+
+// get - Havzadan workspace olish
+func (p *workspacePool) get() *workspace {
+	return p.pool.Get().(*workspace)
+}
+
+// put - Workspace’ni havzaga qaytarish; undan keyin uni ishlatish mumkin emas
+func (p *workspacePool) put(w *workspace) {
+	p.pool.Put(w)
+}
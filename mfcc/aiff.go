@@ -0,0 +1,182 @@
+package mfcc
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// ErrInvalidAIFF - Ma’lumotlar FORM/AIFF yoki FORM/AIFC tuzilmasiga mos kelmaydi
+var ErrInvalidAIFF = errors.New("noto‘g‘ri AIFF ma’lumotlari")
+
+// AIFFFormat - AIFF/AIFF-C faylning COMM bo‘lagidan o‘qilgan parametrlar
+type AIFFFormat struct {
+	AIFC          bool   // Fayl AIFF-C (FORM/AIFC) ko‘rinishida
+	Compression   string // AIFF-C siqish turi ("NONE", "sowt", "fl32", "ulaw" va h.k.); oddiy AIFF uchun "NONE"
+	Channels      int    // Kanallar soni
+	SampleRate    int    // Namunalar tezligi (Hz, 80 bitli kengaytirilgan sondan yaxlitlangan)
+	BitsPerSample int    // Namuna bit chuqurligi (COMM dagi qiymat)
+	NumFrames     int    // Har bir kanaldagi namunalar soni
+}
+
+// DecodeAIFF r dan AIFF yoki AIFF-C ma’lumotlarini o‘qiydi va har bir kanal namunalarini [-1, 1] oralig‘ida
+// qaytaradi ([kanal][namuna]). Big-endian PCM 8-32 bit, AIFF-C "NONE", "sowt" (little-endian), "fl32"/"fl64"
+// va "ulaw"/"alaw" qo‘llab-quvvatlanadi; boshqa siqish turlari uchun errors.ErrUnsupported o‘ralgan xatolik qaytariladi.
+func DecodeAIFF(r io.Reader) ([][]float32, AIFFFormat, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return nil, AIFFFormat{}, fmt.Errorf("%w: FORM sarlavhasini o‘qib bo‘lmadi: %v", ErrInvalidAIFF, err)
+	}
+	formType := string(header[8:12])
+	if string(header[0:4]) != "FORM" || formType != "AIFF" && formType != "AIFC" {
+		return nil, AIFFFormat{}, fmt.Errorf("%w: FORM/AIFF sarlavhasi topilmadi", ErrInvalidAIFF)
+	}
+
+	format := AIFFFormat{AIFC: formType == "AIFC"}
+	haveFormat := false
+	var data []byte
+	haveData := false
+	for !haveFormat || !haveData {
+		var chunk [8]byte
+		if _, err := io.ReadFull(r, chunk[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, format, fmt.Errorf("%w: COMM yoki SSND bo‘lagi topilmadi", ErrInvalidAIFF)
+			}
+			return nil, format, fmt.Errorf("%w: bo‘lak sarlavhasini o‘qib bo‘lmadi: %v", ErrInvalidAIFF, err)
+		}
+		id := string(chunk[:4])
+		size := int64(binary.BigEndian.Uint32(chunk[4:]))
+
+		switch id {
+		case "COMM":
+			if size < 18 || size > maxFormatChunk {
+				return nil, format, fmt.Errorf("%w: COMM bo‘lagi o‘lchami %d", ErrInvalidAIFF, size)
+			}
+			body := make([]byte, size+size&1)
+			if _, err := io.ReadFull(r, body); err != nil {
+				return nil, format, fmt.Errorf("%w: COMM bo‘lagini o‘qib bo‘lmadi: %v", ErrInvalidAIFF, err)
+			}
+			var err error
+			if format, err = parseAIFFFormat(body[:size], format.AIFC); err != nil {
+				return nil, format, err
+			}
+			haveFormat = true
+		case "SSND":
+			// SSND bo‘lagi COMM dan oldin kelishi mumkin, shuning uchun ma’lumotlar xotirada saqlanadi
+			if size < 8 {
+				return nil, format, fmt.Errorf("%w: SSND bo‘lagi o‘lchami %d", ErrInvalidAIFF, size)
+			}
+			var prefix [8]byte
+			if _, err := io.ReadFull(r, prefix[:]); err != nil {
+				return nil, format, fmt.Errorf("%w: SSND sarlavhasini o‘qib bo‘lmadi: %v", ErrInvalidAIFF, err)
+			}
+			offset := int64(binary.BigEndian.Uint32(prefix[0:4]))
+			if offset > size-8 {
+				return nil, format, fmt.Errorf("%w: SSND offset %d bo‘lak o‘lchamidan katta", ErrInvalidAIFF, offset)
+			}
+			body, err := io.ReadAll(io.LimitReader(r, size-8))
+			if err != nil {
+				return nil, format, fmt.Errorf("AIFF ma’lumotlarini o‘qishda xatolik: %w", err)
+			}
+			if size&1 == 1 {
+				// Toq o‘lchamdan keyingi to‘ldiruvchi bayt (fayl oxirida bo‘lmasligi ham mumkin)
+				io.CopyN(io.Discard, r, 1)
+			}
+			data = body[min(offset, int64(len(body))):]
+			haveData = true
+		default:
+			// Noma’lum bo‘laklarni (MARK, INST, NAME, ANNO va h.k.) o‘tkazib yuborish
+			if _, err := io.CopyN(io.Discard, r, size+size&1); err != nil {
+				return nil, format, fmt.Errorf("%w: %q bo‘lagini o‘tkazib bo‘lmadi: %v", ErrInvalidAIFF, id, err)
+			}
+		}
+	}
+
+	decode, sampleSize, err := aiffSampleDecoder(format)
+	if err != nil {
+		return nil, format, err
+	}
+	// COMM dagi namunalar sonidan ortiq baytlar (masalan, blok tekislash) tashlanadi
+	data = data[:min(len(data), format.NumFrames*format.Channels*sampleSize)]
+	return deinterleave(data, format.Channels, sampleSize, decode), format, nil
+}
+
+// parseAIFFFormat - COMM bo‘lagini tahlil qilish (AIFF-C da siqish turi bilan)
+func parseAIFFFormat(body []byte, aifc bool) (AIFFFormat, error) {
+	be := binary.BigEndian
+	format := AIFFFormat{
+		AIFC:          aifc,
+		Compression:   "NONE",
+		Channels:      int(be.Uint16(body[0:2])),
+		NumFrames:     int(be.Uint32(body[2:6])),
+		BitsPerSample: int(be.Uint16(body[6:8])),
+		SampleRate:    int(math.Round(extendedToFloat64(body[8:18]))),
+	}
+	if aifc {
+		if len(body) < 22 {
+			return format, fmt.Errorf("%w: AIFF-C COMM bo‘lagi juda qisqa (%d bayt)", ErrInvalidAIFF, len(body))
+		}
+		format.Compression = string(body[18:22])
+	}
+	if format.Channels == 0 || format.SampleRate <= 0 {
+		return format, fmt.Errorf("%w: %d kanal, %d Hz", ErrInvalidAIFF, format.Channels, format.SampleRate)
+	}
+	if _, _, err := aiffSampleDecoder(format); err != nil {
+		return format, err
+	}
+	return format, nil
+}
+
+// aiffSampleDecoder - Siqish turi bo‘yicha namuna dekoderi va namuna o‘lchami (bayt).
+// PCM namunalari konteynerning yuqori bitlarida joylashgani uchun konteynerning to‘liq shkalasiga bo‘linadi.
+func aiffSampleDecoder(format AIFFFormat) (func(b []byte) float32, int, error) {
+	bytesPerSample := (format.BitsPerSample + 7) / 8
+	switch format.Compression {
+	case "NONE", "twos":
+		if bytesPerSample >= 1 && bytesPerSample <= 4 {
+			return pcmSampleDecoder(bytesPerSample, true), bytesPerSample, nil
+		}
+	case "sowt":
+		if bytesPerSample >= 1 && bytesPerSample <= 4 {
+			return pcmSampleDecoder(bytesPerSample, false), bytesPerSample, nil
+		}
+	case "fl32", "FL32":
+		return func(b []byte) float32 { return math.Float32frombits(binary.BigEndian.Uint32(b)) }, 4, nil
+	case "fl64", "FL64":
+		return func(b []byte) float32 { return float32(math.Float64frombits(binary.BigEndian.Uint64(b))) }, 8, nil
+	case "ulaw", "ULAW":
+		return g711SampleDecoder(&muLawTable), 1, nil
+	case "alaw", "ALAW":
+		return g711SampleDecoder(&aLawTable), 1, nil
+	}
+	return nil, 0, fmt.Errorf("AIFF siqish turi %q, %d bit: %w", format.Compression, format.BitsPerSample, errors.ErrUnsupported)
+}
+
+// extendedToFloat64 - 80 bitli IEEE 754 kengaytirilgan aniqlikdagi sonni (big-endian) float64 ga o‘girish
+func extendedToFloat64(b []byte) float64 {
+	exponent := int(binary.BigEndian.Uint16(b[0:2]))
+	mantissa := binary.BigEndian.Uint64(b[2:10]) // Butun qism biti aniq saqlanadi
+	sign := 1.0
+	if exponent&0x8000 != 0 {
+		sign = -1
+	}
+	exponent &= 0x7FFF
+	if exponent == 0 && mantissa == 0 {
+		return 0
+	}
+	if exponent == 0x7FFF {
+		return math.NaN()
+	}
+	return sign * math.Ldexp(float64(mantissa), exponent-16383-63)
+}
+
+// decodeAIFFBuffer - DecodeAIFF ni registr uchun o‘rash
+func decodeAIFFBuffer(r io.Reader, _ LoadOptions) (*AudioBuffer, error) {
+	channels, format, err := DecodeAIFF(r)
+	if err != nil {
+		return nil, err
+	}
+	return &AudioBuffer{SampleRate: format.SampleRate, Channels: channels}, nil
+}
@@ -0,0 +1,134 @@
+package mfcc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"testing"
+)
+
+// buildAIFF - Berilgan xom namunalar bilan AIFF (compression bo‘sh bo‘lsa) yoki AIFF-C fayl yaratish.
+// SSND bo‘lagi COMM dan oldin, undan oldin esa toq o‘lchamli NAME bo‘lagi yoziladi.
+func buildAIFF(compression string, bits, channels, numFrames int, rate float64, data []byte) []byte {
+	be := binary.BigEndian
+	comm := be.AppendUint16(nil, uint16(channels))
+	comm = be.AppendUint32(comm, uint32(numFrames))
+	comm = be.AppendUint16(comm, uint16(bits))
+	// 80 bitli kengaytirilgan son: eksponenta va aniq butun bitli mantissa
+	exp := math.Ilogb(rate)
+	comm = be.AppendUint16(comm, uint16(exp+16383))
+	comm = be.AppendUint64(comm, uint64(math.Ldexp(rate, 63-exp)))
+	formType := "AIFF"
+	if compression != "" {
+		formType = "AIFC"
+		comm = append(comm, compression...)
+		comm = append(comm, 0, 0) // Bo‘sh Pascal satr (nomi) va tekislash bayti
+	}
+
+	var body bytes.Buffer
+	body.WriteString(formType)
+	writeChunk := func(id string, payload []byte) {
+		body.WriteString(id)
+		binary.Write(&body, be, uint32(len(payload)))
+		body.Write(payload)
+		if len(payload)%2 == 1 {
+			body.WriteByte(0)
+		}
+	}
+	writeChunk("NAME", []byte("tst"))
+	// SSND: offset=2, blockSize=0, keyin 2 bayt o‘tkaziladigan to‘ldiruvchi
+	writeChunk("SSND", append([]byte{0, 0, 0, 2, 0, 0, 0, 0, 0xAA, 0xAA}, data...))
+	writeChunk("COMM", comm)
+
+	var out bytes.Buffer
+	out.WriteString("FORM")
+	binary.Write(&out, be, uint32(body.Len()))
+	out.Write(body.Bytes())
+	return out.Bytes()
+}
+
+func TestDecodeAIFFFormats(t *testing.T) {
+	be := binary.BigEndian
+	// Har bir formatda [-1, -0.5, 0, 0.5] qiymatlari
+	want := []float32{-1, -0.5, 0, 0.5}
+
+	var s16be, s16le, f32 []byte
+	var s24 []byte
+	for _, v := range want {
+		s16be = be.AppendUint16(s16be, uint16(int16(v*32768)))
+		s16le = binary.LittleEndian.AppendUint16(s16le, uint16(int16(v*32768)))
+		f32 = be.AppendUint32(f32, math.Float32bits(v))
+		x := int32(v * 8388608)
+		s24 = append(s24, byte(x>>16), byte(x>>8), byte(x))
+	}
+
+	cases := []struct {
+		name        string
+		compression string
+		bits        int
+		channels    int
+		data        []byte
+	}{
+		{"pcm8", "", 8, 1, []byte{0x80, 0xC0, 0x00, 0x40}},
+		{"pcm16", "", 16, 1, s16be},
+		{"pcm24", "", 24, 1, s24},
+		{"pcm16_stereo", "", 16, 2, s16be},
+		{"aifc_none", "NONE", 16, 1, s16be},
+		{"aifc_sowt", "sowt", 16, 1, s16le},
+		{"aifc_fl32", "fl32", 32, 1, f32},
+		// G.711: 0x00 -> -32124, 0x80 -> 32124 (µ-law); 0xFF va 0x7F nolga yaqin
+		{"aifc_ulaw", "ulaw", 16, 1, []byte{0x00, 0xFF, 0x7F, 0x80}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			numFrames := len(want) / tc.channels
+			// Ma’lumot oxiridagi ortiqcha bayt numFrames bo‘yicha tashlanishi kerak
+			data := append(bytes.Clone(tc.data), 0x7F)
+			channels, format, err := DecodeAIFF(bytes.NewReader(buildAIFF(tc.compression, tc.bits, tc.channels, numFrames, 22050, data)))
+			if err != nil {
+				t.Fatalf("DecodeAIFF xatolik: %v", err)
+			}
+			if format.SampleRate != 22050 || format.Channels != tc.channels || format.NumFrames != numFrames {
+				t.Errorf("kutilmagan format: %+v", format)
+			}
+			tolerance := 1e-6
+			if tc.compression == "ulaw" {
+				tolerance = 0.02 // µ-law diapazoni ±32124/32768 va nol atrofidagi qadam
+			}
+			for c, channel := range channels {
+				if len(channel) != numFrames {
+					t.Fatalf("kanal %d: %d namuna, kutilgan %d", c, len(channel), numFrames)
+				}
+				for i, got := range channel {
+					expected := want[i*len(channels)+c]
+					if tc.compression == "ulaw" {
+						expected = []float32{-1, 0, 0, 1}[i]
+					}
+					if math.Abs(float64(got-expected)) > tolerance {
+						t.Errorf("kanal %d namuna %d: %v, kutilgan %v", c, i, got, expected)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeAIFFErrors(t *testing.T) {
+	ima := buildAIFF("ima4", 16, 1, 1, 8000, []byte{0, 0})
+	if _, _, err := DecodeAIFF(bytes.NewReader(ima)); !errors.Is(err, errors.ErrUnsupported) {
+		t.Errorf("ima4 uchun errors.ErrUnsupported kutilgan edi, olindi %v", err)
+	}
+	for _, data := range [][]byte{nil, []byte("FORM\x00\x00\x00\x04WAVE"), []byte("FORM\x00\x00\x00\x04AIFF")} {
+		if _, _, err := DecodeAIFF(bytes.NewReader(data)); !errors.Is(err, ErrInvalidAIFF) {
+			t.Errorf("%q uchun ErrInvalidAIFF kutilgan edi, olindi %v", data, err)
+		}
+	}
+
+	// Registr orqali format aniqlanadi
+	buf, format, err := DecodeAudio(bytes.NewReader(buildAIFF("", 16, 1, 1, 44100, []byte{0x40, 0x00})))
+	if err != nil || format != "aiff" || buf.SampleRate != 44100 || buf.Channels[0][0] != 0.5 {
+		t.Errorf("DecodeAudio: %q, %+v, %v", format, buf, err)
+	}
+}
@@ -1,24 +1,166 @@
 package mfcc
 
 import (
+	"errors"
 	"fmt"
+	"os"
+	"strings"
+)
+
+// AudioBuffer ko‘p kanalli audio signalni ifodalaydi: har bir kanal alohida slice, barchasi bir xil uzunlikda.
+type AudioBuffer struct {
+	SampleRate int         // Namunalar tezligi (Hz)
+	Channels   [][]float32 // [kanal][namuna], qiymatlar [-1, 1] oralig‘ida
+}
 
-	"github.com/DylanMeeus/GoAudio/wave"
+// ChannelMode ko‘p kanalli audiodan qaysi signal(lar) olinishini belgilaydi.
+type ChannelMode string
+
+const (
+	ChannelDownmix  ChannelMode = "downmix"  // Kanallarning o‘rtachasi (mono), standart
+	ChannelSelect   ChannelMode = "select"   // Faqat ChannelOptions.Index kanali
+	ChannelSeparate ChannelMode = "separate" // Har bir kanal alohida (ko‘p mikrofonli yozuvlar uchun)
 )
 
-// LoadAudio WAV faylni o‘qib, float32 formatida qaytaradi
+// ChannelOptions kanallarni tanlash sozlamalari.
+type ChannelOptions struct {
+	Mode  ChannelMode // Bo‘sh bo‘lsa ChannelDownmix
+	Index int         // ChannelSelect uchun kanal indeksi (0 dan boshlanadi)
+}
+
+// NumChannels kanallar sonini qaytaradi.
+func (b *AudioBuffer) NumChannels() int {
+	return len(b.Channels)
+}
+
+// Len har bir kanaldagi namunalar sonini qaytaradi.
+func (b *AudioBuffer) Len() int {
+	if len(b.Channels) == 0 {
+		return 0
+	}
+	return len(b.Channels[0])
+}
+
+// Downmix kanallarning o‘rtachasini mono signal sifatida qaytaradi.
+// Bitta kanalli buferda kanalning o‘zi (nusxasiz) qaytariladi.
+func (b *AudioBuffer) Downmix() []float32 {
+	if len(b.Channels) == 1 {
+		return b.Channels[0]
+	}
+	mono := make([]float32, b.Len())
+	scale := 1 / float32(len(b.Channels))
+	for _, channel := range b.Channels {
+		for i, v := range channel {
+			mono[i] += v * scale
+		}
+	}
+	return mono
+}
+
+// Signals ChannelOptions bo‘yicha qayta ishlanadigan signallarni qaytaradi:
+// downmix va select uchun bitta, separate uchun har bir kanal uchun bittadan.
+func (b *AudioBuffer) Signals(opts ChannelOptions) ([][]float32, error) {
+	if len(b.Channels) == 0 {
+		return nil, errors.New("audio buferida kanal yo‘q")
+	}
+	switch opts.Mode {
+	case "", ChannelDownmix:
+		return [][]float32{b.Downmix()}, nil
+	case ChannelSelect:
+		if opts.Index < 0 || opts.Index >= len(b.Channels) {
+			return nil, fmt.Errorf("kanal %d mavjud emas (%d kanal)", opts.Index, len(b.Channels))
+		}
+		return [][]float32{b.Channels[opts.Index]}, nil
+	case ChannelSeparate:
+		return b.Channels, nil
+	default:
+		return nil, fmt.Errorf("noma’lum kanal rejimi %q", opts.Mode)
+	}
+}
+
+// LoadAudioBuffer audio faylni barcha kanallari bilan o‘qiydi. Format fayl kengaytmasidan emas,
+// boshlang‘ich baytlaridan aniqlanadi (DecodeAudio ga qarang).
+func LoadAudioBuffer(filename string) (*AudioBuffer, error) {
+	return loadAudioBuffer(filename, LoadOptions{})
+}
+
+// loadAudioBuffer - Faylni ochib DecodeAudioWithOptions ga uzatish
+func loadAudioBuffer(filename string, opts LoadOptions) (*AudioBuffer, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("audio faylni ochishda xatolik: %w", err)
+	}
+	defer file.Close()
+
+	buf, format, err := DecodeAudioWithOptions(file, opts)
+	if err != nil {
+		if format == "" {
+			return nil, fmt.Errorf("audio faylni dekodlashda xatolik: %w", err)
+		}
+		return nil, fmt.Errorf("%s faylni dekodlashda xatolik: %w", strings.ToUpper(format), err)
+	}
+	return buf, nil
+}
+
+// LoadOptions audio faylni o‘qish sozlamalari.
+type LoadOptions struct {
+	TargetSampleRate int             // Natija namunalar tezligi (0 - fayldagi tezlik saqlanadi)
+	Quality          ResampleQuality // Qayta namunalash sifati (bo‘sh bo‘lsa ResampleHigh)
+	VerifyMD5        bool            // FLAC fayllarda namunalarni STREAMINFO dagi MD5 bilan tekshirish
+}
+
+// LoadAudio audio faylni (WAV, FLAC yoki RegisterDecoder orqali qo‘shilgan format) o‘qib,
+// mono float32 signal ([-1, 1] oralig‘ida) va namunalar tezligini qaytaradi.
+// Ko‘p kanalli fayllarda kanallarning o‘rtachasi olinadi; kanallarni alohida olish uchun LoadAudioBuffer dan foydalaning.
 func LoadAudio(filename string) ([]float32, int, error) {
-	// WAV faylni dekodlash
-	wav, err := wave.ReadWaveFile(filename)
+	return LoadAudioWithOptions(filename, LoadOptions{})
+}
+
+// LoadAudioWithOptions LoadAudio kabi ishlaydi, lekin TargetSampleRate berilsa signalni shu tezlikka o‘tkazadi.
+func LoadAudioWithOptions(filename string, opts LoadOptions) ([]float32, int, error) {
+	// Avval monoga o‘tkazib, keyin bitta signalni qayta namunalash arzonroq
+	buf, err := loadAudioBuffer(filename, LoadOptions{VerifyMD5: opts.VerifyMD5})
 	if err != nil {
-		return nil, 0, fmt.Errorf("WAV faylni dekodlashda xatolik: %v", err)
+		return nil, 0, err
+	}
+	audio := buf.Downmix()
+	if opts.TargetSampleRate == 0 || opts.TargetSampleRate == buf.SampleRate {
+		return audio, buf.SampleRate, nil
 	}
+	if audio, err = Resample(audio, buf.SampleRate, opts.TargetSampleRate, opts.Quality); err != nil {
+		return nil, 0, fmt.Errorf("audio namunalar tezligini o‘zgartirishda xatolik: %w", err)
+	}
+	return audio, opts.TargetSampleRate, nil
+}
 
-	// Frame'larni float32 formatiga o‘tkazish va normalizatsiya qilish
-	data := make([]float32, len(wav.Frames))
-	for i, frame := range wav.Frames {
-		data[i] = float32(frame) / 32768.0 // 16-bit audio uchun normalizatsiya
+// LoadAudio audio faylni o‘qib, protsessorning SampleRate tezligidagi mono signalni qaytaradi.
+// Fayl boshqa tezlikda bo‘lsa, Config.ResampleQuality sifatida avtomatik qayta namunalanadi.
+func (p *Processor) LoadAudio(filename string) ([]float32, error) {
+	cfg := p.proc.Config()
+	audio, _, err := LoadAudioWithOptions(filename, LoadOptions{
+		TargetSampleRate: cfg.SampleRate,
+		Quality:          ResampleQuality(cfg.ResampleQuality),
+	})
+	return audio, err
+}
+
+// ProcessChannels AudioBuffer dan ChannelOptions bo‘yicha tanlangan signallarning MFCC xususiyatlarini hisoblaydi.
+// Natija [signal][ramka][koeffitsient] ko‘rinishida: downmix va select uchun bitta, separate uchun har bir kanal uchun bittadan.
+// Bufer namunalar tezligi SampleRate dan farq qilsa, signallar avtomatik qayta namunalanadi.
+func (p *Processor) ProcessChannels(buf *AudioBuffer, opts ChannelOptions) ([][][]float32, error) {
+	signals, err := buf.Signals(opts)
+	if err != nil {
+		return nil, err
 	}
 
-	return data, wav.WaveFmt.SampleRate, nil
+	results := make([][][]float32, len(signals))
+	for i, signal := range signals {
+		if signal, err = p.proc.Resample(signal, buf.SampleRate); err != nil {
+			return nil, fmt.Errorf("kanal %d: %w", i, err)
+		}
+		if results[i], err = p.Process(signal); err != nil {
+			return nil, fmt.Errorf("kanal %d: %w", i, err)
+		}
+	}
+	return results, nil
 }
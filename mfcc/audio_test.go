@@ -0,0 +1,77 @@
+package mfcc
+
+import (
+	"testing"
+)
+
+func TestAudioBufferSignals(t *testing.T) {
+	buf := &AudioBuffer{SampleRate: 16000, Channels: [][]float32{{1, 0, -1}, {0, 0.5, 1}}}
+	if buf.NumChannels() != 2 || buf.Len() != 3 {
+		t.Fatalf("NumChannels=%d Len=%d", buf.NumChannels(), buf.Len())
+	}
+
+	mono := buf.Downmix()
+	for i, want := range []float32{0.5, 0.25, 0} {
+		if mono[i] != want {
+			t.Errorf("downmix namuna %d: %v, kutilgan %v", i, mono[i], want)
+		}
+	}
+
+	selected, err := buf.Signals(ChannelOptions{Mode: ChannelSelect, Index: 1})
+	if err != nil || len(selected) != 1 || selected[0][1] != 0.5 {
+		t.Errorf("select natijasi %v, xatolik %v", selected, err)
+	}
+	if _, err := buf.Signals(ChannelOptions{Mode: ChannelSelect, Index: 2}); err == nil {
+		t.Error("mavjud bo‘lmagan kanal uchun xatolik kutilgan edi")
+	}
+	if separate, _ := buf.Signals(ChannelOptions{Mode: ChannelSeparate}); len(separate) != 2 {
+		t.Errorf("separate: %d signal, kutilgan 2", len(separate))
+	}
+}
+
+func TestProcessChannels(t *testing.T) {
+	cfg := DefaultConfig()
+	processor := newTestProcessor(t, cfg)
+
+	left, right := testSignal(4000), noiseSignal(4000, 7)
+	buf := &AudioBuffer{SampleRate: cfg.SampleRate, Channels: [][]float32{left, right}}
+
+	results, err := processor.ProcessChannels(buf, ChannelOptions{Mode: ChannelSeparate})
+	if err != nil {
+		t.Fatalf("ProcessChannels xatolik: %v", err)
+	}
+	for c, signal := range [][]float32{left, right} {
+		want, err := processor.Process(signal)
+		if err != nil {
+			t.Fatalf("Process xatolik: %v", err)
+		}
+		if len(results[c]) != len(want) {
+			t.Fatalf("kanal %d: %d ramka, kutilgan %d", c, len(results[c]), len(want))
+		}
+		for i := range want {
+			for k := range want[i] {
+				if results[c][i][k] != want[i][k] {
+					t.Fatalf("kanal %d ramka %d qiymat %d: %v, kutilgan %v", c, i, k, results[c][i][k], want[i][k])
+				}
+			}
+		}
+	}
+
+	// Boshqa tezlikdagi bufer avtomatik ravishda SampleRate ga o‘tkaziladi
+	buf.SampleRate = 8000
+	results, err = processor.ProcessChannels(buf, ChannelOptions{Mode: ChannelSelect, Index: 0})
+	if err != nil {
+		t.Fatalf("ProcessChannels xatolik: %v", err)
+	}
+	resampled, err := Resample(left, 8000, cfg.SampleRate, ResampleHigh)
+	if err != nil {
+		t.Fatalf("Resample xatolik: %v", err)
+	}
+	want, err := processor.Process(resampled)
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+	if len(results[0]) != len(want) {
+		t.Errorf("qayta namunalangan kanal: %d ramka, kutilgan %d", len(results[0]), len(want))
+	}
+}
@@ -0,0 +1,42 @@
+package mfcc
+
+import "github.com/BaxtiyorUrolov/go-mfcc/internal"
+
+// O‘rnatilgan backend nomlari
+const (
+	BackendCPU  = internal.BackendCPU  // Toza Go’dagi etalon amalga oshirish
+	BackendCUDA = internal.BackendCUDA // CUDA orqali GPU’da hisoblash (`cuda` build tegi)
+)
+
+// ErrUnknownBackend ro‘yxatdan o‘tmagan backend nomi so‘ralganda qaytariladi.
+var ErrUnknownBackend = internal.ErrUnknownBackend
+
+// Backend power spectrum, Mel filtrlash, log va DCT bosqichlarini ramkalar to‘plami ustida bajaradi.
+// Uchinchi tomon backend’lari (SIMD, OpenCL va h.k.) shu interfeysni amalga oshiradi.
+type Backend = internal.Backend
+
+// BackendSpec backend yaratish uchun kerakli parametrlar.
+type BackendSpec = internal.BackendSpec
+
+// BackendResult backend ramkalar to‘plami uchun hisoblagan natijalar.
+type BackendResult = internal.BackendResult
+
+// BackendFactory berilgan parametrlar bo‘yicha yangi backend yaratadi.
+type BackendFactory = internal.BackendFactory
+
+// RegisterBackend yangi backend’ni nomi bilan ro‘yxatdan o‘tkazadi.
+// Keyin uni Config.Backend orqali tanlash mumkin.
+func RegisterBackend(name string, factory BackendFactory) error {
+	return internal.RegisterBackend(name, factory)
+}
+
+// Backends ro‘yxatdan o‘tgan backend nomlarini qaytaradi.
+func Backends() []string {
+	return internal.Backends()
+}
+
+// NewCPUBackend etalon CPU backend’ini yaratadi. Boshqa backend’lar natijalarini
+// solishtirish yoki uni o‘rab olish uchun foydali.
+func NewCPUBackend(spec BackendSpec) (Backend, error) {
+	return internal.NewCPUBackend(spec)
+}
@@ -0,0 +1,216 @@
+package mfcc
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+// fakeBackend - Dispatch va xatoliklarni tekshirish uchun soxta backend
+type fakeBackend struct {
+	cpu    Backend
+	err    error
+	frames *int64
+}
+
+func (b *fakeBackend) Name() string { return "fake" }
+
+func (b *fakeBackend) Compute(frames [][]float32) (*BackendResult, error) {
+	atomic.AddInt64(b.frames, int64(len(frames)))
+	if b.err != nil {
+		return nil, b.err
+	}
+	return b.cpu.Compute(frames)
+}
+
+func (b *fakeBackend) Close() error { return nil }
+
+var fakeSeq int64
+
+// registerFake - Soxta backend’ni noyob nom bilan ro‘yxatdan o‘tkazish.
+// Ro‘yxatdan o‘tkazilgan nom va backend olgan ramkalar hisoblagichi qaytariladi.
+func registerFake(t *testing.T, prefix string, computeErr, createErr error) (string, *int64) {
+	t.Helper()
+	name := fmt.Sprintf("%s-%d", prefix, atomic.AddInt64(&fakeSeq, 1))
+	var frames int64
+	err := RegisterBackend(name, func(spec BackendSpec) (Backend, error) {
+		if createErr != nil {
+			return nil, createErr
+		}
+		cpu, err := NewCPUBackend(spec)
+		if err != nil {
+			return nil, err
+		}
+		return &fakeBackend{cpu: cpu, err: computeErr, frames: &frames}, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterBackend xatolik: %v", err)
+	}
+	return name, &frames
+}
+
+func testSignal(n int) []float32 {
+	audio := make([]float32, n)
+	for i := range audio {
+		audio[i] = float32(i%100) / 100
+	}
+	return audio
+}
+
+func TestBackendDispatch(t *testing.T) {
+	name, frames := registerFake(t, "fake-dispatch", nil, nil)
+
+	cfg := DefaultConfig()
+	cfg.Backend = name
+	processor, err := NewProcessor(cfg)
+	if err != nil {
+		t.Fatalf("NewProcessor xatolik: %v", err)
+	}
+	defer processor.Close()
+
+	if got := processor.BackendName(); got != "fake" {
+		t.Fatalf("backend nomi %q, kutilgan %q", got, "fake")
+	}
+
+	mfccs, err := processor.Process(testSignal(cfg.FrameLength * 4))
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+	if n := atomic.LoadInt64(frames); n != int64(len(mfccs)) {
+		t.Fatalf("backend %d ramka oldi, kutilgan %d", n, len(mfccs))
+	}
+}
+
+func TestBackendErrorPropagation(t *testing.T) {
+	errBoom := errors.New("boom")
+	name, _ := registerFake(t, "fake-error", errBoom, nil)
+
+	cfg := DefaultConfig()
+	cfg.Backend = name
+	processor, err := NewProcessor(cfg)
+	if err != nil {
+		t.Fatalf("NewProcessor xatolik: %v", err)
+	}
+	defer processor.Close()
+
+	if _, err := processor.Process(testSignal(cfg.FrameLength * 4)); !errors.Is(err, errBoom) {
+		t.Fatalf("kutilgan backend xatoligi, olindi: %v", err)
+	}
+}
+
+func TestBackendFallback(t *testing.T) {
+	failing, _ := registerFake(t, "fake-fallback", errors.New("boom"), nil)
+	broken, _ := registerFake(t, "fake-broken", nil, errors.New("qurilma topilmadi"))
+
+	cfg := DefaultConfig()
+	reference, err := NewProcessor(cfg)
+	if err != nil {
+		t.Fatalf("NewProcessor xatolik: %v", err)
+	}
+	defer reference.Close()
+	audio := testSignal(cfg.FrameLength * 4)
+	want, err := reference.Process(audio)
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+
+	for _, name := range []string{failing, broken} {
+		cfg.Backend = name
+		cfg.BackendFallback = true
+		processor, err := NewProcessor(cfg)
+		if err != nil {
+			t.Fatalf("%s: NewProcessor xatolik: %v", name, err)
+		}
+		got, err := processor.Process(audio)
+		processor.Close()
+		if err != nil {
+			t.Fatalf("%s: zaxira backend bilan Process xatolik: %v", name, err)
+		}
+		if len(got) != len(want) {
+			t.Fatalf("%s: %d ramka, kutilgan %d", name, len(got), len(want))
+		}
+		for i := range want {
+			for j := range want[i] {
+				if got[i][j] != want[i][j] {
+					t.Fatalf("%s: ramka %d koeffitsient %d: %v != %v", name, i, j, got[i][j], want[i][j])
+				}
+			}
+		}
+	}
+}
+
+func TestUnknownBackend(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Backend = "mavjud-emas"
+	if _, err := NewProcessor(cfg); !errors.Is(err, ErrUnknownBackend) {
+		t.Fatalf("kutilgan ErrUnknownBackend, olindi: %v", err)
+	}
+}
+
+func TestCPUBackendConcurrent(t *testing.T) {
+	// Protsessor yaratgan BackendSpec ni ushlab olish
+	var spec BackendSpec
+	name := fmt.Sprintf("spec-%d", atomic.AddInt64(&fakeSeq, 1))
+	err := RegisterBackend(name, func(s BackendSpec) (Backend, error) {
+		spec = s
+		return NewCPUBackend(s)
+	})
+	if err != nil {
+		t.Fatalf("RegisterBackend xatolik: %v", err)
+	}
+	cfg := DefaultConfig()
+	cfg.Backend = name
+	cfg.MaxConcurrency = 2
+	newTestProcessor(t, cfg)
+
+	backend, err := NewCPUBackend(spec)
+	if err != nil {
+		t.Fatalf("NewCPUBackend xatolik: %v", err)
+	}
+	fftLength := 2 * (len(spec.FilterBanks[0]) - 1)
+
+	// Har bir goroutine o‘z ramkalarini hisoblaydi; goroutine’lar soni MaxConcurrency dan ancha ko‘p
+	const workers = 32
+	inputs := make([][][]float32, workers)
+	want := make([]*BackendResult, workers)
+	for w := range inputs {
+		signal := noiseSignal(8*fftLength, int64(w+1))
+		for i := 0; i < 8; i++ {
+			inputs[w] = append(inputs[w], signal[i*fftLength:(i+1)*fftLength])
+		}
+		if want[w], err = backend.Compute(inputs[w]); err != nil {
+			t.Fatalf("Compute xatolik: %v", err)
+		}
+	}
+
+	got := make([]*BackendResult, workers)
+	errs := make([]error, workers)
+	var wg sync.WaitGroup
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func(w int) {
+			defer wg.Done()
+			got[w], errs[w] = backend.Compute(inputs[w])
+		}(w)
+	}
+	wg.Wait()
+
+	// Natijalar boshqa chaqiruvlar tugagandan keyin ham o‘zgarmagan bo‘lishi kerak (havza buferlari bilan umumiy emas)
+	for w := range got {
+		if errs[w] != nil {
+			t.Fatalf("goroutine %d: %v", w, errs[w])
+		}
+		for i := range want[w].MFCC {
+			if len(got[w].MFCC[i]) != cfg.NumCoefficients || len(got[w].MelEnergies[i]) != cfg.NumFilters {
+				t.Fatalf("goroutine %d ramka %d: %d MFCC, %d Mel", w, i, len(got[w].MFCC[i]), len(got[w].MelEnergies[i]))
+			}
+			for k := range want[w].MFCC[i] {
+				if got[w].MFCC[i][k] != want[w].MFCC[i][k] {
+					t.Fatalf("goroutine %d ramka %d koeffitsient %d: %v != %v", w, i, k, got[w].MFCC[i][k], want[w].MFCC[i][k])
+				}
+			}
+		}
+	}
+}
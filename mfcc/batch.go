@@ -0,0 +1,123 @@
+package mfcc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// ItemError partiyadagi bitta audio signalni qayta ishlashdagi xatolik.
+type ItemError struct {
+	Index int   // Audio signalning partiyadagi indeksi
+	Err   error // Process qaytargan xatolik
+}
+
+// Error - Xatolik matni
+func (e *ItemError) Error() string {
+	return fmt.Sprintf("audio %d: %v", e.Index, e.Err)
+}
+
+// Unwrap - Asl xatolikni qaytarish (errors.Is/As uchun)
+func (e *ItemError) Unwrap() error {
+	return e.Err
+}
+
+// BatchError ProcessBatch da bir yoki bir nechta audio xatolik bilan tugaganda qaytariladi.
+// Xatoliklar indeks bo‘yicha tartiblangan; errors.Is va errors.As har bir element xatoligini tekshiradi
+// (masalan, errors.Is(err, ErrAudioTooShort)).
+type BatchError struct {
+	Items []*ItemError // Xatolik bilan tugagan audiolar
+	Total int          // Partiyadagi audiolar soni
+}
+
+// Error - Xatolik matni
+func (e *BatchError) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "partiyadagi %d/%d audio xatolik bilan tugadi", len(e.Items), e.Total)
+	for i, item := range e.Items {
+		if i == 3 {
+			fmt.Fprintf(&b, "; yana %d ta", len(e.Items)-i)
+			break
+		}
+		b.WriteString("; ")
+		b.WriteString(item.Error())
+	}
+	return b.String()
+}
+
+// Unwrap - Element xatoliklarini qaytarish
+func (e *BatchError) Unwrap() []error {
+	errs := make([]error, len(e.Items))
+	for i, item := range e.Items {
+		errs[i] = item
+	}
+	return errs
+}
+
+// Indexes xatolik bilan tugagan audiolar indekslarini o‘sish tartibida qaytaradi.
+func (e *BatchError) Indexes() []int {
+	indexes := make([]int, len(e.Items))
+	for i, item := range e.Items {
+		indexes[i] = item.Index
+	}
+	return indexes
+}
+
+// BatchOptions ProcessBatchWithOptions sozlamalari.
+type BatchOptions struct {
+	// FailFast birinchi xatolikdan keyin yangi audiolarni boshlamaydi. Qayta ishlanmay qolgan
+	// audiolar natijasi nil bo‘ladi va BatchError ga kirmaydi.
+	FailFast bool
+}
+
+// ProcessBatch bir nechta audio signallarni parallel ravishda qayta ishlaydi. Audiolar ishchilarga umumiy
+// navbatdan taqsimlanadi va har bir audio ichidagi parallel hisoblash bilan bitta MaxConcurrency byudjetini bo‘lishadi.
+// Xatolik bilan tugagan audiolar natijasi nil bo‘ladi, qolganlari hisoblanadi va *BatchError qaytariladi.
+func (p *Processor) ProcessBatch(audios [][]float32) ([][][]float32, error) {
+	return p.ProcessBatchWithOptions(audios, BatchOptions{})
+}
+
+// ProcessBatchWithOptions ProcessBatch kabi ishlaydi; opts.FailFast bilan birinchi xatolikda to‘xtaydi.
+func (p *Processor) ProcessBatchWithOptions(audios [][]float32, opts BatchOptions) ([][][]float32, error) {
+	return p.ProcessBatchContext(context.Background(), audios, opts)
+}
+
+// ProcessBatchContext ProcessBatchWithOptions kabi ishlaydi; ctx bekor qilinsa yoki muddati tugasa ishchilar
+// navbatdagi audioni boshlamaydi, hisoblanayotgan audio ham to‘xtatiladi va ctx.Err() qaytariladi.
+// Bu holda natijada faqat bekor qilinishdan oldin tugagan audiolar bo‘ladi.
+func (p *Processor) ProcessBatchContext(ctx context.Context, audios [][]float32, opts BatchOptions) ([][][]float32, error) {
+	if len(audios) == 0 {
+		return nil, errors.New("bo‘sh audio partiyasi")
+	}
+
+	results := make([][][]float32, len(audios))
+	errs := make([]error, len(audios))
+	// Audiolar umumiy navbatdan olinadi: uzun fayllar bitta ishchida to‘planib qolmaydi
+	p.proc.RunJobs(ctx, len(audios), func(j int) error {
+		mfccs, err := p.ProcessContext(ctx, audios[j])
+		if err != nil {
+			errs[j] = err
+			if opts.FailFast {
+				return err
+			}
+			return nil
+		}
+		results[j] = mfccs
+		return nil
+	})
+	if err := ctx.Err(); err != nil {
+		return results, err
+	}
+	if !slices.ContainsFunc(errs, func(err error) bool { return err != nil }) {
+		return results, nil
+	}
+	batchErr := &BatchError{Total: len(audios)}
+	for i, err := range errs {
+		if err != nil {
+			batchErr.Items = append(batchErr.Items, &ItemError{Index: i, Err: err})
+		}
+	}
+	return results, batchErr
+}
@@ -0,0 +1,118 @@
+package mfcc
+
+import (
+	"errors"
+	"fmt"
+	"runtime"
+	"slices"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestProcessBatchErrors(t *testing.T) {
+	processor := newTestProcessor(t, DefaultConfig())
+	audio := noiseSignal(8000, 1)
+	audios := [][]float32{audio, nil, audio[:100], audio}
+
+	results, err := processor.ProcessBatch(audios)
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) {
+		t.Fatalf("BatchError kutilgan edi, olindi %v", err)
+	}
+	if got := batchErr.Indexes(); !slices.Equal(got, []int{1, 2}) || batchErr.Total != 4 {
+		t.Errorf("xatolik indekslari %v, jami %d", got, batchErr.Total)
+	}
+	if !errors.Is(err, ErrAudioTooShort) {
+		t.Errorf("errors.Is(err, ErrAudioTooShort) false: %v", err)
+	}
+	if results[0] == nil || results[3] == nil || results[1] != nil || results[2] != nil {
+		t.Errorf("faqat muvaffaqiyatli audiolar natijasi bo‘lishi kerak: %v", []bool{
+			results[0] != nil, results[1] != nil, results[2] != nil, results[3] != nil,
+		})
+	}
+}
+
+func TestProcessBatchFailFast(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.MaxConcurrency = 1 // Audiolar ketma-ket qayta ishlanadi
+	processor := newTestProcessor(t, cfg)
+	audio := noiseSignal(8000, 2)
+
+	results, err := processor.ProcessBatchWithOptions([][]float32{audio, audio[:100], audio, audio}, BatchOptions{FailFast: true})
+	var batchErr *BatchError
+	if !errors.As(err, &batchErr) || !slices.Equal(batchErr.Indexes(), []int{1}) {
+		t.Fatalf("1-audio uchun BatchError kutilgan edi, olindi %v", err)
+	}
+	if results[0] == nil || results[2] != nil || results[3] != nil {
+		t.Error("xatolikdan keyingi audiolar qayta ishlanmasligi kerak edi")
+	}
+}
+
+// budgetBackend - Bir vaqtda ishlayotgan Compute chaqiruvlari sonini kuzatuvchi backend
+type budgetBackend struct {
+	cpu               Backend
+	inFlight, maxSeen *int64
+}
+
+func (b *budgetBackend) Name() string { return "budget" }
+
+func (b *budgetBackend) Compute(frames [][]float32) (*BackendResult, error) {
+	n := atomic.AddInt64(b.inFlight, 1)
+	defer atomic.AddInt64(b.inFlight, -1)
+	for {
+		seen := atomic.LoadInt64(b.maxSeen)
+		if n <= seen || atomic.CompareAndSwapInt64(b.maxSeen, seen, n) {
+			break
+		}
+	}
+	time.Sleep(time.Millisecond) // Chaqiruvlar ustma-ust tushishi uchun
+	return b.cpu.Compute(frames)
+}
+
+func (b *budgetBackend) Close() error { return nil }
+
+func TestProcessBatchConcurrencyBudget(t *testing.T) {
+	defer runtime.GOMAXPROCS(runtime.GOMAXPROCS(8))
+
+	var inFlight, maxSeen int64
+	name := fmt.Sprintf("budget-%d", atomic.AddInt64(&fakeSeq, 1))
+	err := RegisterBackend(name, func(spec BackendSpec) (Backend, error) {
+		cpu, err := NewCPUBackend(spec)
+		if err != nil {
+			return nil, err
+		}
+		return &budgetBackend{cpu: cpu, inFlight: &inFlight, maxSeen: &maxSeen}, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterBackend xatolik: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Backend = name
+	cfg.Parallel = true
+	cfg.MaxConcurrency = 3
+	processor := newTestProcessor(t, cfg)
+
+	// Bitta uzun va bir nechta qisqa audio: partiya va ramkalar darajasidagi parallellik birga ishlaydi
+	audios := [][]float32{noiseSignal(5*cfg.SampleRate, 1)}
+	for i := 0; i < 6; i++ {
+		audios = append(audios, noiseSignal(cfg.SampleRate/4, int64(i+2)))
+	}
+	results, err := processor.ProcessBatch(audios)
+	if err != nil {
+		t.Fatalf("ProcessBatch xatolik: %v", err)
+	}
+	for i, audio := range audios {
+		want, err := processor.Process(audio)
+		if err != nil {
+			t.Fatalf("Process xatolik: %v", err)
+		}
+		if len(results[i]) != len(want) {
+			t.Errorf("audio %d: %d ramka, kutilgan %d", i, len(results[i]), len(want))
+		}
+	}
+	if maxSeen > int64(cfg.MaxConcurrency) {
+		t.Errorf("bir vaqtda %d ta hisoblash, byudjet %d", maxSeen, cfg.MaxConcurrency)
+	}
+}
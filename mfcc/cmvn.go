@@ -0,0 +1,40 @@
+package mfcc
+
+import "github.com/BaxtiyorUrolov/go-mfcc/internal"
+
+// CMVNMode - Kepstral o‘rtacha va dispersiya normalizatsiyasi (CMVN) turi
+type CMVNMode string
+
+const (
+	CMVNNone      CMVNMode = "none"      // Normalizatsiya yo‘q
+	CMVNUtterance CMVNMode = "utterance" // Butun audio bo‘yicha har bir koeffitsient uchun
+	CMVNSliding   CMVNMode = "sliding"   // Sirpanuvchi oyna (Kaldi `apply-cmvn-sliding` uslubida)
+	CMVNGlobal    CMVNMode = "global"    // Dataset bo‘yicha yig‘ilgan global statistika
+)
+
+// ErrNoCMVNStats global CMVN tanlangan, lekin statistika berilmaganda qaytariladi.
+var ErrNoCMVNStats = internal.ErrNoCMVNStats
+
+// CMVNStats dataset bo‘yicha yig‘iladigan CMVN statistikasi.
+type CMVNStats = internal.CMVNStats
+
+// NewCMVNStats berilgan o‘lchamdagi bo‘sh statistika yaratadi.
+// O‘lcham Config.NumCoefficients ga teng bo‘lishi kerak.
+func NewCMVNStats(dim int) *CMVNStats {
+	return internal.NewCMVNStats(dim)
+}
+
+// LoadCMVNStats Save orqali saqlangan statistikani fayldan o‘qiydi.
+func LoadCMVNStats(filename string) (*CMVNStats, error) {
+	return internal.LoadCMVNStats(filename)
+}
+
+// AccumulateCMVN audio signalning normallashtirilmagan MFCC larini (deltalarsiz) statistikaga qo‘shadi.
+func (p *Processor) AccumulateCMVN(stats *CMVNStats, audio []float32) error {
+	return p.proc.AccumulateCMVN(stats, audio)
+}
+
+// SetCMVNStats global CMVN uchun statistikani o‘rnatadi (CMVNStatsFile o‘rniga).
+func (p *Processor) SetCMVNStats(stats *CMVNStats) {
+	p.proc.SetCMVNStats(stats)
+}
@@ -0,0 +1,244 @@
+package mfcc
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"path/filepath"
+	"testing"
+)
+
+// noiseSignal - Takrorlanuvchi psevdo-tasodifiy signal (amplitudasi vaqt bo‘yicha o‘zgaradi)
+func noiseSignal(n int, seed int64) []float32 {
+	rng := rand.New(rand.NewSource(seed))
+	audio := make([]float32, n)
+	for i := range audio {
+		envelope := 0.2 + 0.8*math.Abs(math.Sin(float64(i)/4000))
+		audio[i] = float32(envelope * (rng.Float64()*2 - 1))
+	}
+	return audio
+}
+
+// columnStats - Har bir koeffitsient bo‘yicha o‘rtacha va dispersiya
+func columnStats(frames [][]float32) (mean, variance []float64) {
+	dim := len(frames[0])
+	mean = make([]float64, dim)
+	variance = make([]float64, dim)
+	for _, f := range frames {
+		for k, v := range f {
+			mean[k] += float64(v)
+		}
+	}
+	for k := range mean {
+		mean[k] /= float64(len(frames))
+	}
+	for _, f := range frames {
+		for k, v := range f {
+			d := float64(v) - mean[k]
+			variance[k] += d * d
+		}
+	}
+	for k := range variance {
+		variance[k] /= float64(len(frames))
+	}
+	return mean, variance
+}
+
+func newTestProcessor(t *testing.T, cfg Config) *Processor {
+	t.Helper()
+	processor, err := NewProcessor(cfg)
+	if err != nil {
+		t.Fatalf("NewProcessor xatolik: %v", err)
+	}
+	t.Cleanup(func() { processor.Close() })
+	return processor
+}
+
+func TestUtteranceCMVN(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CMVN = CMVNUtterance
+	cfg.CMVNNormVars = true
+	processor := newTestProcessor(t, cfg)
+
+	mfccs, err := processor.Process(noiseSignal(16000, 1))
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+	mean, variance := columnStats(mfccs)
+	for k := range mean {
+		if math.Abs(mean[k]) > 1e-4 || math.Abs(variance[k]-1) > 1e-3 {
+			t.Fatalf("koeffitsient %d: o‘rtacha %v, dispersiya %v", k, mean[k], variance[k])
+		}
+	}
+}
+
+func TestSlidingCMVNMatchesUtteranceForLongWindow(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CMVN = CMVNUtterance
+	utterance := newTestProcessor(t, cfg)
+	cfg.CMVN = CMVNSliding
+	cfg.CMVNWindow = 1000 // Audio uzunligidan katta oyna
+	sliding := newTestProcessor(t, cfg)
+
+	audio := noiseSignal(16000, 2)
+	want, err := utterance.Process(audio)
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+	got, err := sliding.Process(audio)
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+	for i := range want {
+		for k := range want[i] {
+			if math.Abs(float64(got[i][k]-want[i][k])) > 1e-3 {
+				t.Fatalf("ramka %d koeffitsient %d: %v != %v", i, k, got[i][k], want[i][k])
+			}
+		}
+	}
+}
+
+func TestSlidingCMVNCentered(t *testing.T) {
+	cfg := DefaultConfig()
+	raw := newTestProcessor(t, cfg)
+	cfg.CMVN = CMVNSliding
+	cfg.CMVNWindow = 10
+	cfg.CMVNCenter = true
+	sliding := newTestProcessor(t, cfg)
+
+	audio := noiseSignal(16000, 3)
+	base, err := raw.Process(audio)
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+	got, err := sliding.Process(audio)
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+
+	// O‘rtadagi ramka uchun oyna [t-5, t+5)
+	t0 := len(base) / 2
+	for k := range base[t0] {
+		var mean float64
+		for j := t0 - 5; j < t0+5; j++ {
+			mean += float64(base[j][k])
+		}
+		mean /= 10
+		want := float64(base[t0][k]) - mean
+		if math.Abs(float64(got[t0][k])-want) > 1e-3 {
+			t.Fatalf("koeffitsient %d: %v, kutilgan %v", k, got[t0][k], want)
+		}
+	}
+}
+
+func TestGlobalCMVN(t *testing.T) {
+	cfg := DefaultConfig()
+	accumulator := newTestProcessor(t, cfg)
+
+	audios := [][]float32{noiseSignal(8000, 4), noiseSignal(12000, 5)}
+	stats := NewCMVNStats(cfg.NumCoefficients)
+	for _, audio := range audios {
+		if err := accumulator.AccumulateCMVN(stats, audio); err != nil {
+			t.Fatalf("AccumulateCMVN xatolik: %v", err)
+		}
+	}
+	statsFile := filepath.Join(t.TempDir(), "cmvn.json")
+	if err := stats.Save(statsFile); err != nil {
+		t.Fatalf("Save xatolik: %v", err)
+	}
+
+	cfg.CMVN = CMVNGlobal
+	cfg.CMVNNormVars = true
+	if _, err := newTestProcessor(t, cfg).Process(audios[0]); !errors.Is(err, ErrNoCMVNStats) {
+		t.Fatalf("kutilgan ErrNoCMVNStats, olindi: %v", err)
+	}
+
+	cfg.CMVNStatsFile = statsFile
+	processor := newTestProcessor(t, cfg)
+	var all [][]float32
+	for _, audio := range audios {
+		mfccs, err := processor.Process(audio)
+		if err != nil {
+			t.Fatalf("Process xatolik: %v", err)
+		}
+		all = append(all, mfccs...)
+	}
+	mean, variance := columnStats(all)
+	for k := range mean {
+		if math.Abs(mean[k]) > 1e-3 || math.Abs(variance[k]-1) > 1e-2 {
+			t.Fatalf("koeffitsient %d: o‘rtacha %v, dispersiya %v", k, mean[k], variance[k])
+		}
+	}
+}
+
+func TestStreamerCMVNMatchesProcess(t *testing.T) {
+	audio := noiseSignal(40000, 7)
+	stats := NewCMVNStats(DefaultConfig().NumCoefficients)
+	if err := newTestProcessor(t, DefaultConfig()).AccumulateCMVN(stats, audio); err != nil {
+		t.Fatalf("AccumulateCMVN xatolik: %v", err)
+	}
+
+	for _, mode := range []CMVNMode{CMVNGlobal, CMVNSliding} {
+		cfg := DefaultConfig()
+		cfg.CMVN = mode
+		cfg.CMVNWindow = 50
+		cfg.CMVNNormVars = true
+		cfg.DeltaOrder = 1
+		processor := newTestProcessor(t, cfg)
+		processor.SetCMVNStats(stats)
+		want, err := processor.Process(audio)
+		if err != nil {
+			t.Fatalf("%s: Process xatolik: %v", mode, err)
+		}
+
+		streamer := processor.NewStreamer()
+		if err := streamer.Err(); err != nil {
+			t.Fatalf("%s: NewStreamer xatolik: %v", mode, err)
+		}
+		for start := 0; start < len(audio); start += 700 {
+			streamer.Write(audio[start:min(start+700, len(audio))])
+		}
+		streamer.Flush()
+		got := make([][]float32, len(want))
+		for i := range got {
+			got[i] = streamer.Read()
+		}
+		streamer.Close()
+		assertSameFrames(t, got, want)
+	}
+
+	cfg := DefaultConfig()
+	cfg.CMVN = CMVNUtterance
+	streamer := newTestProcessor(t, cfg).NewStreamer()
+	defer streamer.Close()
+	if streamer.Err() == nil {
+		t.Error("utterance CMVN uchun xatolik kutilgan edi")
+	}
+	streamer.Write(audio)
+	streamer.Flush()
+	if got := streamer.Read(); got != nil {
+		t.Errorf("yaratilmagan streamer Read nil qaytarishi kerak edi, olindi %v", got)
+	}
+}
+
+func TestProcessBatchDoesNotNormalize(t *testing.T) {
+	cfg := DefaultConfig()
+	processor := newTestProcessor(t, cfg)
+
+	audio := noiseSignal(8000, 6)
+	want, err := processor.Process(audio)
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+	got, err := processor.ProcessBatch([][]float32{audio})
+	if err != nil {
+		t.Fatalf("ProcessBatch xatolik: %v", err)
+	}
+	for i := range want {
+		for k := range want[i] {
+			if got[0][i][k] != want[i][k] {
+				t.Fatalf("ramka %d koeffitsient %d: %v != %v", i, k, got[0][i][k], want[i][k])
+			}
+		}
+	}
+}
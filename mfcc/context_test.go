@@ -0,0 +1,104 @@
+package mfcc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+// cancelBackend - Birinchi Compute chaqiruvida kontekstni bekor qiluvchi backend
+type cancelBackend struct {
+	cpu    Backend
+	cancel context.CancelFunc
+	frames *int64
+}
+
+func (b *cancelBackend) Name() string { return "cancel" }
+
+func (b *cancelBackend) Compute(frames [][]float32) (*BackendResult, error) {
+	atomic.AddInt64(b.frames, int64(len(frames)))
+	b.cancel()
+	return b.cpu.Compute(frames)
+}
+
+func (b *cancelBackend) Close() error { return nil }
+
+func TestProcessContextCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	name := fmt.Sprintf("cancel-%d", atomic.AddInt64(&fakeSeq, 1))
+	var frames int64
+	err := RegisterBackend(name, func(spec BackendSpec) (Backend, error) {
+		cpu, err := NewCPUBackend(spec)
+		if err != nil {
+			return nil, err
+		}
+		return &cancelBackend{cpu: cpu, cancel: cancel, frames: &frames}, nil
+	})
+	if err != nil {
+		t.Fatalf("RegisterBackend xatolik: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.Backend = name
+	processor := newTestProcessor(t, cfg)
+
+	audio := noiseSignal(10*cfg.SampleRate, 1) // ~1000 ramka
+	if _, err := processor.ProcessContext(ctx, audio); !errors.Is(err, context.Canceled) {
+		t.Fatalf("context.Canceled kutilgan edi, olindi %v", err)
+	}
+	if n := atomic.LoadInt64(&frames); n >= 500 {
+		t.Errorf("bekor qilingandan keyin hisoblash davom etgan: %d ramka", n)
+	}
+}
+
+func TestProcessBatchContext(t *testing.T) {
+	processor := newTestProcessor(t, DefaultConfig())
+	audios := [][]float32{noiseSignal(8000, 1), noiseSignal(8000, 2)}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := processor.ProcessBatchContext(ctx, audios, BatchOptions{})
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("context.Canceled kutilgan edi, olindi %v", err)
+	}
+	for i, r := range results {
+		if r != nil {
+			t.Errorf("audio %d bekor qilingan kontekstda hisoblangan", i)
+		}
+	}
+
+	results, err = processor.ProcessBatchContext(context.Background(), audios, BatchOptions{})
+	if err != nil || results[0] == nil || results[1] == nil {
+		t.Errorf("bekor qilinmagan kontekst: %v", err)
+	}
+}
+
+func TestStreamerContext(t *testing.T) {
+	processor := newTestProcessor(t, DefaultConfig())
+	ctx, cancel := context.WithCancel(context.Background())
+	streamer := processor.NewStreamerContext(ctx)
+	if err := streamer.Err(); err != nil {
+		t.Fatalf("NewStreamerContext xatolik: %v", err)
+	}
+	defer streamer.Close()
+
+	done := make(chan []float32)
+	go func() { done <- streamer.Read() }()
+	cancel()
+	select {
+	case got := <-done:
+		if got != nil {
+			t.Errorf("bekor qilingandan keyin nil kutilgan edi, olindi %d qiymat", len(got))
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("Read kontekst bekor qilinganda qaytmadi")
+	}
+	if !errors.Is(streamer.Err(), context.Canceled) {
+		t.Errorf("Err: %v", streamer.Err())
+	}
+}
@@ -0,0 +1,200 @@
+package mfcc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+)
+
+// ErrUnknownFormat ma’lumotlar boshi hech bir ro‘yxatdan o‘tgan dekoderning belgisiga mos kelmaganda qaytariladi.
+var ErrUnknownFormat = errors.New("noma’lum audio formati")
+
+// AudioDecoderFunc r dan audio ma’lumotlarini AudioBuffer ga dekodlaydi.
+// opts dan faqat dekoderga tegishli maydonlar (masalan, VerifyMD5) ishlatiladi;
+// TargetSampleRate ni DecodeAudioWithOptions o‘zi qo‘llaydi.
+type AudioDecoderFunc func(r io.Reader, opts LoadOptions) (*AudioBuffer, error)
+
+// audioFormat - Ro‘yxatdagi bitta dekoder
+type audioFormat struct {
+	name   string
+	magic  []string
+	decode AudioDecoderFunc
+}
+
+var (
+	decodersMu sync.RWMutex
+	// Keyin ro‘yxatdan o‘tganlar oldinroq tekshiriladi, shuning uchun o‘rnatilgan formatlar oxirida
+	decoders = []audioFormat{
+		{name: "flac", magic: []string{"fLaC"}, decode: decodeFLACBuffer},
+		{name: "wav", magic: []string{"RIFF????WAVE"}, decode: decodeWAVBuffer},
+		{name: "aiff", magic: []string{"FORM????AIFF", "FORM????AIFC"}, decode: decodeAIFFBuffer},
+	}
+)
+
+// RegisterDecoder yangi audio formatni nomi va boshlang‘ich belgilari (magic) bilan ro‘yxatdan o‘tkazadi.
+// Belgida '?' ixtiyoriy baytga mos keladi (masalan, "RIFF????WAVE"). DecodeAudio va LoadAudio
+// keyin ro‘yxatdan o‘tgan dekoderlarni birinchi tekshiradi, shuning uchun o‘rnatilgan formatni
+// (masalan, WAV ichidagi boshqa kodek uchun) o‘z dekoderingiz bilan almashtirish mumkin.
+func RegisterDecoder(name string, decode AudioDecoderFunc, magic ...string) error {
+	if name == "" {
+		return errors.New("dekoder nomi bo‘sh bo‘lmasligi kerak")
+	}
+	if decode == nil {
+		return errors.New("dekodlash funksiyasi nil bo‘lmasligi kerak")
+	}
+	if len(magic) == 0 {
+		return errors.New("kamida bitta boshlang‘ich belgi kerak")
+	}
+	for _, m := range magic {
+		if m == "" {
+			return errors.New("boshlang‘ich belgi bo‘sh bo‘lmasligi kerak")
+		}
+	}
+
+	decodersMu.Lock()
+	defer decodersMu.Unlock()
+	for _, f := range decoders {
+		if f.name == name {
+			return fmt.Errorf("%q dekoder allaqachon ro‘yxatdan o‘tgan", name)
+		}
+	}
+	decoders = append([]audioFormat{{name: name, magic: magic, decode: decode}}, decoders...)
+	return nil
+}
+
+// Decoders ro‘yxatdan o‘tgan dekoder nomlarini tekshirish tartibida qaytaradi.
+func Decoders() []string {
+	decodersMu.RLock()
+	defer decodersMu.RUnlock()
+	names := make([]string, len(decoders))
+	for i, f := range decoders {
+		names[i] = f.name
+	}
+	return names
+}
+
+// DecodeAudio r dan audio ma’lumotlarini o‘qiydi; format boshlang‘ich baytlardan aniqlanadi.
+// Fayl, HTTP javob tanasi, arxiv ichidagi fayl yoki xotiradagi bayt massivi bilan ishlaydi.
+// Natija bilan birga aniqlangan format nomi ("wav", "flac", "aiff" yoki RegisterDecoder dagi nom) qaytariladi.
+// Sarlavhasiz xom PCM ni belgidan aniqlab bo‘lmaydi, uning uchun DecodeRawPCM dan foydalaning.
+func DecodeAudio(r io.Reader) (*AudioBuffer, string, error) {
+	return DecodeAudioWithOptions(r, LoadOptions{})
+}
+
+// DecodeAudioWithOptions DecodeAudio kabi ishlaydi; TargetSampleRate berilsa barcha kanallar shu tezlikka o‘tkaziladi.
+func DecodeAudioWithOptions(r io.Reader, opts LoadOptions) (*AudioBuffer, string, error) {
+	br, ok := r.(*bufio.Reader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	format, err := sniffAudioFormat(br)
+	if err != nil {
+		return nil, "", err
+	}
+
+	buf, err := format.decode(br, opts)
+	if err != nil {
+		return nil, format.name, err
+	}
+	if opts.TargetSampleRate != 0 && opts.TargetSampleRate != buf.SampleRate {
+		if buf, err = buf.Resample(opts.TargetSampleRate, opts.Quality); err != nil {
+			return nil, format.name, fmt.Errorf("audio namunalar tezligini o‘zgartirishda xatolik: %w", err)
+		}
+	}
+	return buf, format.name, nil
+}
+
+// sniffAudioFormat - Boshlang‘ich baytlarni o‘qimasdan (Peek) ko‘rib, mos dekoderni tanlash.
+// Hech bir belgi mos kelmasa va ma’lumotlar ID3v2 tegi bilan boshlansa (masalan, FLAC yoki MP3), teg o‘tkazib
+// yuboriladi va format undan keyingi baytlardan aniqlanadi; shunda MP3 fayl FLAC dekoderiga tushmaydi.
+func sniffAudioFormat(br *bufio.Reader) (audioFormat, error) {
+	format, header, err := matchAudioFormat(br)
+	if err != nil || format.decode != nil {
+		return format, err
+	}
+	if len(header) < 10 || string(header[:3]) != "ID3" {
+		return audioFormat{}, fmt.Errorf("%w: boshlanishi %q", ErrUnknownFormat, header[:min(len(header), 12)])
+	}
+
+	if _, err := br.Discard(id3v2Length(header)); err != nil {
+		return audioFormat{}, fmt.Errorf("ID3 tegini o‘tkazib bo‘lmadi: %w", err)
+	}
+	format, header, err = matchAudioFormat(br)
+	if err != nil || format.decode != nil {
+		return format, err
+	}
+	return audioFormat{}, fmt.Errorf("%w: ID3 tegidan keyin boshlanishi %q", ErrUnknownFormat, header[:min(len(header), 12)])
+}
+
+// matchAudioFormat - Boshlang‘ich baytlarga mos dekoder (topilmasa decode nil) va ko‘rilgan baytlar
+func matchAudioFormat(br *bufio.Reader) (audioFormat, []byte, error) {
+	decodersMu.RLock()
+	formats := decoders
+	decodersMu.RUnlock()
+
+	// ID3v2 sarlavhasi uchun kamida 10 bayt
+	longest := 10
+	for _, f := range formats {
+		for _, m := range f.magic {
+			longest = max(longest, len(m))
+		}
+	}
+	// Qisqa ma’lumotlarda Peek xatolik bilan birga mavjud baytlarni qaytaradi
+	header, err := br.Peek(longest)
+	if len(header) == 0 && err != nil && !errors.Is(err, io.EOF) {
+		return audioFormat{}, nil, fmt.Errorf("audio ma’lumotlarini o‘qishda xatolik: %w", err)
+	}
+
+	for _, f := range formats {
+		for _, m := range f.magic {
+			if matchMagic(m, header) {
+				return f, header, nil
+			}
+		}
+	}
+	return audioFormat{}, header, nil
+}
+
+// id3v2Length - 10 baytlik ID3v2 sarlavhasidan tegning to‘liq uzunligi (sarlavha va footer bilan).
+// O‘lcham 4 ta 7 bitli "syncsafe" baytda saqlanadi.
+func id3v2Length(header []byte) int {
+	size := int(header[6])<<21 | int(header[7])<<14 | int(header[8])<<7 | int(header[9])
+	size += 10
+	if header[5]&0x10 != 0 {
+		size += 10
+	}
+	return size
+}
+
+// matchMagic - header magic belgisi bilan boshlanadimi ('?' ixtiyoriy bayt)
+func matchMagic(magic string, header []byte) bool {
+	if len(header) < len(magic) {
+		return false
+	}
+	for i := 0; i < len(magic); i++ {
+		if magic[i] != '?' && magic[i] != header[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// decodeWAVBuffer - DecodeWAV ni registr uchun o‘rash
+func decodeWAVBuffer(r io.Reader, _ LoadOptions) (*AudioBuffer, error) {
+	channels, format, err := DecodeWAV(r)
+	if err != nil {
+		return nil, err
+	}
+	return &AudioBuffer{SampleRate: format.SampleRate, Channels: channels}, nil
+}
+
+// decodeFLACBuffer - DecodeFLACWithOptions ni registr uchun o‘rash
+func decodeFLACBuffer(r io.Reader, opts LoadOptions) (*AudioBuffer, error) {
+	channels, info, err := DecodeFLACWithOptions(r, FLACOptions{VerifyMD5: opts.VerifyMD5})
+	if err != nil {
+		return nil, err
+	}
+	return &AudioBuffer{SampleRate: info.SampleRate, Channels: channels}, nil
+}
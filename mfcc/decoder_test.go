@@ -0,0 +1,106 @@
+package mfcc
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+func TestDecodeAudioSniffing(t *testing.T) {
+	for _, tc := range []struct{ file, format string }{
+		{"mono16.wav", "wav"},
+		{"stereo24.flac", "flac"},
+	} {
+		// MultiReader bytes.Reader ni yashiradi: HTTP javob tanasi kabi oddiy io.Reader
+		r := io.MultiReader(bytes.NewReader(readFixture(t, tc.file)))
+		buf, format, err := DecodeAudio(r)
+		if err != nil {
+			t.Fatalf("%s: DecodeAudio xatolik: %v", tc.file, err)
+		}
+		if format != tc.format || buf.Len() == 0 {
+			t.Errorf("%s: format %q, %d namuna", tc.file, format, buf.Len())
+		}
+	}
+
+	buf, _, err := DecodeAudioWithOptions(bytes.NewReader(readFixture(t, "mono16.flac")), LoadOptions{TargetSampleRate: 8000})
+	if err != nil {
+		t.Fatalf("DecodeAudioWithOptions xatolik: %v", err)
+	}
+	if buf.SampleRate != 8000 || buf.Len() != 2000 {
+		t.Errorf("qayta namunalangan bufer: %d Hz, %d namuna", buf.SampleRate, buf.Len())
+	}
+
+	for _, data := range []string{"", "OggS\x00\x02", "RIFF\x24\x00\x00\x00AVI "} {
+		if _, _, err := DecodeAudio(strings.NewReader(data)); !errors.Is(err, ErrUnknownFormat) {
+			t.Errorf("%q uchun ErrUnknownFormat kutilgan edi, olindi %v", data, err)
+		}
+	}
+}
+
+// id3Tag - size baytlik bo‘sh ma’lumotli ID3v2.4 tegi
+func id3Tag(size int) []byte {
+	tag := []byte{'I', 'D', '3', 4, 0, 0, byte(size >> 21 & 0x7f), byte(size >> 14 & 0x7f), byte(size >> 7 & 0x7f), byte(size & 0x7f)}
+	return append(tag, make([]byte, size)...)
+}
+
+func TestDecodeAudioID3(t *testing.T) {
+	flac := readFixture(t, "mono16.flac")
+	want, _, err := DecodeFLAC(bytes.NewReader(flac))
+	if err != nil {
+		t.Fatalf("DecodeFLAC xatolik: %v", err)
+	}
+	// bufio buferidan katta teg ham o‘tkazib yuboriladi
+	for _, size := range []int{0, 100, 10000} {
+		data := append(id3Tag(size), flac...)
+		buf, format, err := DecodeAudio(io.MultiReader(bytes.NewReader(data)))
+		if err != nil {
+			t.Fatalf("%d baytlik ID3: DecodeAudio xatolik: %v", size, err)
+		}
+		if format != "flac" || buf.Len() != len(want[0]) {
+			t.Errorf("%d baytlik ID3: format %q, %d namuna", size, format, buf.Len())
+		}
+	}
+
+	// ID3 tegli MP3 (MPEG-1 Layer III ramka sinxronizatsiyasi) FLAC deb qabul qilinmaydi
+	mp3 := append(id3Tag(200), 0xff, 0xfb, 0x90, 0x64, 0, 0, 0, 0, 0, 0, 0, 0)
+	if _, format, err := DecodeAudio(bytes.NewReader(mp3)); !errors.Is(err, ErrUnknownFormat) || errors.Is(err, ErrInvalidFLAC) {
+		t.Errorf("MP3 uchun ErrUnknownFormat kutilgan edi, olindi %q: %v", format, err)
+	}
+}
+
+func TestRegisterDecoder(t *testing.T) {
+	decode := func(r io.Reader, _ LoadOptions) (*AudioBuffer, error) {
+		data, err := io.ReadAll(r)
+		if err != nil {
+			return nil, err
+		}
+		// Sarlavhadan keyingi har bir bayt bitta namuna
+		samples := make([]float32, len(data)-8)
+		for i, b := range data[8:] {
+			samples[i] = float32(int8(b)) / 128
+		}
+		return &AudioBuffer{SampleRate: 8000, Channels: [][]float32{samples}}, nil
+	}
+	if err := RegisterDecoder("test-raw8", decode, "RAW8?v01"); err != nil {
+		t.Fatalf("RegisterDecoder xatolik: %v", err)
+	}
+	if err := RegisterDecoder("test-raw8", decode, "RAW8"); err == nil {
+		t.Error("takroriy nom uchun xatolik kutilgan edi")
+	}
+	if err := RegisterDecoder("test-empty", decode); err == nil {
+		t.Error("belgisiz dekoder uchun xatolik kutilgan edi")
+	}
+	if names := Decoders(); names[0] != "test-raw8" || names[len(names)-1] != "aiff" {
+		t.Errorf("dekoderlar tartibi: %v", names)
+	}
+
+	buf, format, err := DecodeAudio(strings.NewReader("RAW8xv01\x40\xc0"))
+	if err != nil {
+		t.Fatalf("DecodeAudio xatolik: %v", err)
+	}
+	if format != "test-raw8" || buf.Len() != 2 || buf.Channels[0][0] != 0.5 || buf.Channels[0][1] != -0.5 {
+		t.Errorf("kutilmagan natija: %q, %v", format, buf.Channels)
+	}
+}
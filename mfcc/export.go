@@ -8,9 +8,65 @@ import (
 	"github.com/BaxtiyorUrolov/go-mfcc/internal"
 )
 
+// ExportOptions ExportToCSVWithOptions sozlamalari.
+type ExportOptions struct {
+	Features FeatureSet // Yoziladigan xususiyatlar ustunlari (0 bo‘lsa barchasi), odatda Config.Features bilan bir xil
+}
+
+// featureColumn - CSV dagi bitta skalyar xususiyat ustuni
+type featureColumn struct {
+	name  string
+	value func(f *FrameFeatures) string
+}
+
+// featureColumns - To‘plamdagi skalyar xususiyatlar ustunlari; voicing bo‘lsa pitch dan keyin "voiced" va "voiced_prob" qo‘shiladi
+func featureColumns(set FeatureSet, voicing bool) []featureColumn {
+	var columns []featureColumn
+	if set.Has(FeatureZCR) {
+		columns = append(columns, featureColumn{"zcr", func(f *FrameFeatures) string { return fmt.Sprintf("%f", f.ZCR) }})
+	}
+	if set.Has(FeaturePitch) {
+		columns = append(columns, featureColumn{"pitch", func(f *FrameFeatures) string { return fmt.Sprintf("%f", f.Pitch) }})
+		if voicing {
+			columns = append(columns,
+				featureColumn{"voiced", func(f *FrameFeatures) string {
+					if f.Voiced {
+						return "1"
+					}
+					return "0"
+				}},
+				featureColumn{"voiced_prob", func(f *FrameFeatures) string { return fmt.Sprintf("%f", f.VoicedProb) }},
+			)
+		}
+	}
+	if set.Has(FeatureSpectralCentroid) {
+		columns = append(columns, featureColumn{"spectral_centroid", func(f *FrameFeatures) string { return fmt.Sprintf("%f", f.SpectralCentroid) }})
+	}
+	if set.Has(FeatureSpectralRollOff) {
+		columns = append(columns, featureColumn{"spectral_rolloff", func(f *FrameFeatures) string { return fmt.Sprintf("%f", f.SpectralRollOff) }})
+	}
+	if set.Has(FeatureEnergy) {
+		columns = append(columns, featureColumn{"energy", func(f *FrameFeatures) string { return fmt.Sprintf("%f", f.Energy) }})
+	}
+	return columns
+}
+
 // ExportToCSV - Xususiyatlarni CSV faylga eksport qilish
-// Bu funksiya model o‘qitish uchun ma’lumotlarni saqlaydi
+// Bu funksiya model o‘qitish uchun ma’lumotlarni saqlaydi. Barcha xususiyatlar ustunlari yoziladi
+// (ovozlilik ustunlarisiz); faqat hisoblangan ustunlar uchun ExportToCSVWithOptions dan foydalaning.
 func ExportToCSV(features [][]internal.FrameFeatures, labels []string, filename string) error {
+	return exportCSV(features, labels, filename, FeatureAll, featureColumns(FeatureAll, false))
+}
+
+// ExportToCSVWithOptions ExportToCSV kabi, lekin faqat opts.Features dagi xususiyatlar ustunlarini yozadi.
+// Pitch tanlangan bo‘lsa, "pitch" dan keyin "voiced" (0/1) va "voiced_prob" ustunlari qo‘shiladi.
+func ExportToCSVWithOptions(features [][]FrameFeatures, labels []string, filename string, opts ExportOptions) error {
+	set := opts.Features.orAll()
+	return exportCSV(features, labels, filename, set, featureColumns(set, true))
+}
+
+// exportCSV - MFCC (set da bo‘lsa), berilgan skalyar ustunlar va yorliq bilan CSV yozish
+func exportCSV(features [][]FrameFeatures, labels []string, filename string, set FeatureSet, columns []featureColumn) error {
 	file, err := os.Create(filename)
 	if err != nil {
 		return fmt.Errorf("CSV faylni yaratishda xatolik: %v", err)
@@ -18,29 +74,40 @@ func ExportToCSV(features [][]internal.FrameFeatures, labels []string, filename
 	defer file.Close()
 
 	writer := csv.NewWriter(file)
-	// Sarlavhalar: barcha xususiyatlar va yorliq
-	headers := []string{"file_id", "frame_id", "mfcc_0", "mfcc_1", "mfcc_2", "mfcc_3", "mfcc_4", "mfcc_5", "mfcc_6", "mfcc_7", "mfcc_8", "mfcc_9", "mfcc_10", "mfcc_11", "mfcc_12", "zcr", "pitch", "spectral_centroid", "spectral_rolloff", "energy", "label"}
+	// Koeffitsientlar soni birinchi ramkadan aniqlanadi
+	var numCoeffs, numDelta, numDeltaDelta int
+	if set.Has(FeatureMFCC) {
+		numCoeffs, numDelta, numDeltaDelta = coefficientCounts(features)
+	}
+	// Sarlavhalar: tanlangan xususiyatlar va yorliq
+	headers := []string{"file_id", "frame_id"}
+	headers = appendColumns(headers, "mfcc", numCoeffs)
+	headers = appendColumns(headers, "delta", numDelta)
+	headers = appendColumns(headers, "delta_delta", numDeltaDelta)
+	for _, c := range columns {
+		headers = append(headers, c.name)
+	}
+	headers = append(headers, "label")
 	if err := writer.Write(headers); err != nil {
 		return fmt.Errorf("sarlavhalarni yozishda xatolik: %v", err)
 	}
 
 	for i, featureSet := range features {
-		for j, f := range featureSet {
-			record := make([]string, len(headers))
-			record[0] = fmt.Sprintf("%d", i) // Fayl ID
-			record[1] = fmt.Sprintf("%d", j) // Ramka ID
-			for k, val := range f.MFCC[:13] {
-				record[k+2] = fmt.Sprintf("%f", val)
+		for j := range featureSet {
+			f := &featureSet[j]
+			record := make([]string, 0, len(headers))
+			record = append(record, fmt.Sprintf("%d", i)) // Fayl ID
+			record = append(record, fmt.Sprintf("%d", j)) // Ramka ID
+			record = appendValues(record, f.MFCC, numCoeffs)
+			record = appendValues(record, f.Delta, numDelta)
+			record = appendValues(record, f.DeltaDelta, numDeltaDelta)
+			for _, c := range columns {
+				record = append(record, c.value(f))
 			}
-			record[15] = fmt.Sprintf("%f", f.ZCR)
-			record[16] = fmt.Sprintf("%f", f.Pitch)
-			record[17] = fmt.Sprintf("%f", f.SpectralCentroid)
-			record[18] = fmt.Sprintf("%f", f.SpectralRollOff)
-			record[19] = fmt.Sprintf("%f", f.Energy)
 			if i < len(labels) {
-				record[20] = labels[i]
+				record = append(record, labels[i])
 			} else {
-				record[20] = "unknown"
+				record = append(record, "unknown")
 			}
 			if err := writer.Write(record); err != nil {
 				return fmt.Errorf("yozuvni yozishda xatolik: %v", err)
@@ -50,3 +117,34 @@ func ExportToCSV(features [][]internal.FrameFeatures, labels []string, filename
 	writer.Flush()
 	return writer.Error()
 }
+
+// coefficientCounts - Birinchi bo‘sh bo‘lmagan ramkadan MFCC, delta va delta-delta sonlarini aniqlash
+func coefficientCounts(features [][]internal.FrameFeatures) (mfcc, delta, deltaDelta int) {
+	for _, featureSet := range features {
+		if len(featureSet) > 0 {
+			f := featureSet[0]
+			return len(f.MFCC), len(f.Delta), len(f.DeltaDelta)
+		}
+	}
+	return 0, 0, 0
+}
+
+// appendColumns - "prefix_0", "prefix_1", ... ustun nomlarini qo‘shish
+func appendColumns(headers []string, prefix string, n int) []string {
+	for k := 0; k < n; k++ {
+		headers = append(headers, fmt.Sprintf("%s_%d", prefix, k))
+	}
+	return headers
+}
+
+// appendValues - n ta qiymatni qo‘shish, yetishmaganlari bo‘sh qoldiriladi
+func appendValues(record []string, values []float32, n int) []string {
+	for k := 0; k < n; k++ {
+		if k < len(values) {
+			record = append(record, fmt.Sprintf("%f", values[k]))
+		} else {
+			record = append(record, "")
+		}
+	}
+	return record
+}
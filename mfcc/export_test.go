@@ -0,0 +1,84 @@
+package mfcc
+
+import (
+	"encoding/csv"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/BaxtiyorUrolov/go-mfcc/internal"
+)
+
+func TestExportToCSVDeltaColumns(t *testing.T) {
+	features := [][]internal.FrameFeatures{{
+		{MFCC: []float32{1, 2}, Delta: []float32{3, 4}, DeltaDelta: []float32{5, 6}, Energy: 1},
+	}}
+	filename := filepath.Join(t.TempDir(), "features.csv")
+	if err := ExportToCSV(features, []string{"sinf"}, filename); err != nil {
+		t.Fatalf("ExportToCSV xatolik: %v", err)
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("CSV faylni ochishda xatolik: %v", err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV o‘qishda xatolik: %v", err)
+	}
+
+	want := []string{"file_id", "frame_id", "mfcc_0", "mfcc_1", "delta_0", "delta_1", "delta_delta_0", "delta_delta_1",
+		"zcr", "pitch", "spectral_centroid", "spectral_rolloff", "energy", "label"}
+	if len(records) != 2 || len(records[0]) != len(want) {
+		t.Fatalf("kutilmagan CSV tuzilishi: %v", records)
+	}
+	for i, h := range want {
+		if records[0][i] != h {
+			t.Fatalf("ustun %d: %q, kutilgan %q", i, records[0][i], h)
+		}
+	}
+	if records[1][6] != "5.000000" || records[1][len(want)-1] != "sinf" {
+		t.Fatalf("kutilmagan yozuv: %v", records[1])
+	}
+}
+
+func TestExportToCSVSelectedFeatures(t *testing.T) {
+	features := [][]FrameFeatures{{
+		{MFCC: []float32{1, 2}, Pitch: 150, Voiced: true, VoicedProb: 0.9, Energy: 1},
+	}}
+	filename := filepath.Join(t.TempDir(), "features.csv")
+	opts := ExportOptions{Features: FeaturePitch | FeatureEnergy}
+	if err := ExportToCSVWithOptions(features, nil, filename, opts); err != nil {
+		t.Fatalf("ExportToCSVWithOptions xatolik: %v", err)
+	}
+
+	file, err := os.Open(filename)
+	if err != nil {
+		t.Fatalf("CSV faylni ochishda xatolik: %v", err)
+	}
+	defer file.Close()
+	records, err := csv.NewReader(file).ReadAll()
+	if err != nil {
+		t.Fatalf("CSV o‘qishda xatolik: %v", err)
+	}
+
+	// MFCC tanlanmagan, shuning uchun ma’lumotda bo‘lsa ham uning ustunlari yozilmaydi
+	want := [][]string{
+		{"file_id", "frame_id", "pitch", "voiced", "voiced_prob", "energy", "label"},
+		{"0", "0", "150.000000", "1", "0.900000", "1.000000", "unknown"},
+	}
+	if len(records) != len(want) {
+		t.Fatalf("kutilmagan CSV tuzilishi: %v", records)
+	}
+	for i := range want {
+		if len(records[i]) != len(want[i]) {
+			t.Fatalf("qator %d: %v, kutilgan %v", i, records[i], want[i])
+		}
+		for j := range want[i] {
+			if records[i][j] != want[i][j] {
+				t.Fatalf("qator %d, ustun %d: %q, kutilgan %q", i, j, records[i][j], want[i][j])
+			}
+		}
+	}
+}
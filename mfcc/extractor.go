@@ -0,0 +1,148 @@
+package mfcc
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/BaxtiyorUrolov/go-mfcc/internal"
+)
+
+// streamBlockFrames - Fayldan bir marta o‘qiladigan namunalar soni (har bir kanal uchun)
+const streamBlockFrames = 1 << 15
+
+// FrameFunc oqimli hisoblashda har bir tayyor ramka uchun chaqiriladi: index - ramka tartib raqami
+// (FrameTimes bilan vaqtga o‘giriladi), vector - Process qaytaradigan vektor bilan bir xil.
+// Nil bo‘lmagan xatolik qaytarilsa, hisoblash to‘xtatiladi va shu xatolik o‘zgarishsiz qaytariladi.
+type FrameFunc func(index int, vector []float32) error
+
+// Extractor uzun audio signalni bo‘laklab qayta ishlaydi va xususiyatlarni tayyor bo‘lishi bilan FrameFunc ga uzatadi.
+// Pre-emphasis, ramkalar ustma-ustligi, Padding, sirpanuvchi CMVN va deltalar bo‘laklar orasida saqlanadi, shuning uchun
+// natija butun signalga Process qo‘llangani bilan bir xil, xotira esa audio uzunligiga bog‘liq emas.
+type Extractor struct {
+	e     *internal.FrameExtractor
+	index int
+}
+
+// NewExtractor sampleRate tezligidagi signal uchun oqimli ekstraktor yaratadi. Tezlik SampleRate dan farq qilsa,
+// signal oqim bo‘yicha ResampleQuality sifatida qayta namunalanadi. Butun audioni talab qiladigan sozlamalar
+// (CMVNUtterance va LogDB rejimidagi TopDB) uchun xatolik qaytariladi; CMVNGlobal ishlaydi, CMVNSliding esa
+// ramkalarni oynasi to‘lguncha (CMVNCenter da CMVNWindow/2, aks holda dastlabki 100 ramka) kechiktiradi.
+func (p *Processor) NewExtractor(sampleRate int, fn FrameFunc) (*Extractor, error) {
+	if fn == nil {
+		return nil, errors.New("FrameFunc nil bo‘lmasligi kerak")
+	}
+	x := &Extractor{}
+	e, err := p.proc.NewFrameExtractor(sampleRate, func(f internal.FrameFeatures) error {
+		index := x.index
+		x.index++
+		return fn(index, f.Vector())
+	})
+	if err != nil {
+		return nil, fmt.Errorf("oqimli ekstraktorni yaratishda xatolik: %w", err)
+	}
+	x.e = e
+	return x, nil
+}
+
+// Write navbatdagi mono namunalar bo‘lagini qo‘shadi; to‘liq ramkalar darhol hisoblanadi.
+// Bo‘lak o‘lchami ixtiyoriy va natijaga ta’sir qilmaydi.
+func (x *Extractor) Write(samples []float32) error {
+	return x.e.Write(samples)
+}
+
+// Close signal oxirini bildiradi: oxirgi ramkalar (Padding bo‘yicha to‘ldirilgan) va deltalar hisoblanadi.
+// Signal ramka olish uchun juda qisqa bo‘lsa, ErrAudioTooShort qaytariladi.
+func (x *Extractor) Close() error {
+	return x.e.Close()
+}
+
+// ProcessStream r dagi audio ma’lumotlarini bo‘laklab dekodlab, har bir ramka xususiyatlarini fn ga uzatadi.
+// WAV fayllar blok-blok o‘qiladi, shuning uchun ko‘p soatlik yozuvlar ham cheklangan xotirada qayta ishlanadi;
+// boshqa formatlar (FLAC, AIFF va h.k.) avval DecodeAudio bilan to‘liq dekodlanadi. Ko‘p kanallar o‘rtachalanadi,
+// boshqa namunalar tezligi SampleRate ga avtomatik o‘tkaziladi.
+func (p *Processor) ProcessStream(r io.Reader, fn FrameFunc) error {
+	br := bufio.NewReader(r)
+	format, err := sniffAudioFormat(br)
+	if err != nil {
+		return err
+	}
+	if format.name != "wav" {
+		buf, err := format.decode(br, LoadOptions{})
+		if err != nil {
+			return fmt.Errorf("%s ma’lumotlarini dekodlashda xatolik: %w", strings.ToUpper(format.name), err)
+		}
+		return p.processBuffer(buf, fn)
+	}
+
+	wav, err := NewWAVReader(br)
+	if err != nil {
+		return fmt.Errorf("WAV ma’lumotlarini dekodlashda xatolik: %w", err)
+	}
+	x, err := p.NewExtractor(wav.Format().SampleRate, fn)
+	if err != nil {
+		return err
+	}
+	block := make([][]float32, wav.Format().Channels)
+	for c := range block {
+		block[c] = make([]float32, streamBlockFrames)
+	}
+	mono := make([]float32, streamBlockFrames)
+	for {
+		n, err := wav.Read(block)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		if err := x.Write(downmixBlock(block, n, mono)); err != nil {
+			return err
+		}
+	}
+	return x.Close()
+}
+
+// ProcessFile audio faylni ProcessStream orqali bo‘laklab qayta ishlaydi.
+func (p *Processor) ProcessFile(filename string, fn FrameFunc) error {
+	file, err := os.Open(filename)
+	if err != nil {
+		return fmt.Errorf("audio faylni ochishda xatolik: %w", err)
+	}
+	defer file.Close()
+	return p.ProcessStream(file, fn)
+}
+
+// processBuffer - To‘liq dekodlangan buferni ekstraktorga bo‘laklab uzatish
+func (p *Processor) processBuffer(buf *AudioBuffer, fn FrameFunc) error {
+	x, err := p.NewExtractor(buf.SampleRate, fn)
+	if err != nil {
+		return err
+	}
+	audio := buf.Downmix()
+	for start := 0; start < len(audio); start += streamBlockFrames {
+		if err := x.Write(audio[start:min(start+streamBlockFrames, len(audio))]); err != nil {
+			return err
+		}
+	}
+	return x.Close()
+}
+
+// downmixBlock - Blokning birinchi n namunasini monoga o‘tkazish (AudioBuffer.Downmix bilan bir xil tartibda)
+func downmixBlock(block [][]float32, n int, mono []float32) []float32 {
+	if len(block) == 1 {
+		return block[0][:n]
+	}
+	mono = mono[:n]
+	clear(mono)
+	scale := 1 / float32(len(block))
+	for _, channel := range block {
+		for i, v := range channel[:n] {
+			mono[i] += v * scale
+		}
+	}
+	return mono
+}
@@ -0,0 +1,213 @@
+package mfcc
+
+import (
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// extractAll - Signalni berilgan o‘lchamdagi bo‘laklar bilan Extractor orqali hisoblash
+func extractAll(t *testing.T, processor *Processor, sampleRate int, audio []float32, block int) ([][]float32, error) {
+	t.Helper()
+	var got [][]float32
+	x, err := processor.NewExtractor(sampleRate, func(index int, vector []float32) error {
+		if index != len(got) {
+			t.Fatalf("ramka indeksi %d, kutilgan %d", index, len(got))
+		}
+		got = append(got, vector)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for start := 0; start < len(audio); start += block {
+		if err := x.Write(audio[start:min(start+block, len(audio))]); err != nil {
+			return nil, err
+		}
+	}
+	return got, x.Close()
+}
+
+// assertSameFrames - Ikki natija bit darajasida bir xil ekanligini tekshirish
+func assertSameFrames(t *testing.T, got, want [][]float32) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%d ramka, kutilgan %d", len(got), len(want))
+	}
+	for i := range want {
+		for k := range want[i] {
+			if got[i][k] != want[i][k] {
+				t.Fatalf("ramka %d qiymat %d: %v, kutilgan %v", i, k, got[i][k], want[i][k])
+			}
+		}
+	}
+}
+
+func TestExtractorMatchesProcess(t *testing.T) {
+	kaldi, err := DefaultKaldiOptions().Config()
+	if err != nil {
+		t.Fatalf("Kaldi konfiguratsiyasi: %v", err)
+	}
+	kaldi.Dither = 0
+	kaldi.DeltaOrder = 2
+
+	configs := map[string]func(*Config){
+		"none":     func(c *Config) {},
+		"tail":     func(c *Config) { c.Padding = PaddingTail },
+		"center":   func(c *Config) { c.Padding = PaddingCenter },
+		"constant": func(c *Config) { c.Padding, c.PadMode = PaddingCenter, PadConstant },
+		"edge":     func(c *Config) { c.Padding, c.PadMode, c.Parallel = PaddingCenter, PadEdge, true },
+		"deltas":   func(c *Config) { c.DeltaOrder, c.DeltaWindow = 2, 2 },
+		"sliding":  func(c *Config) { c.CMVN, c.CMVNWindow, c.CMVNNormVars, c.DeltaOrder = CMVNSliding, 40, true, 2 },
+		"centered": func(c *Config) { c.CMVN, c.CMVNWindow, c.CMVNCenter = CMVNSliding, 40, true },
+		"kaldi":    func(c *Config) { *c = kaldi },
+	}
+	for name, configure := range configs {
+		t.Run(name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			configure(&cfg)
+			processor := newTestProcessor(t, cfg)
+
+			// 300 namuna - bitta ramkadan qisqa signal (to‘ldirish aksi signal uzunligidan oshadi)
+			for _, n := range []int{300, 16000} {
+				audio := noiseSignal(n, int64(n))
+				want, wantErr := processor.Process(audio)
+				for _, block := range []int{1, 250, 4096, n} {
+					if block == 1 && n > 1000 {
+						continue
+					}
+					got, err := extractAll(t, processor, cfg.SampleRate, audio, block)
+					if wantErr != nil {
+						if !errors.Is(err, ErrAudioTooShort) {
+							t.Errorf("%d namuna: ErrAudioTooShort kutilgan edi, olindi %v", n, err)
+						}
+						continue
+					}
+					if err != nil {
+						t.Fatalf("%d namuna, %d blok: %v", n, block, err)
+					}
+					assertSameFrames(t, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestExtractorSlidingCMVNLong(t *testing.T) {
+	// 300 dan ortiq ramka: oyna cmvnMinWindow dan keyin faqat orqaga qaraydi va eski yig‘indilar tashlanadi
+	for _, center := range []bool{false, true} {
+		cfg := DefaultConfig()
+		cfg.CMVN = CMVNSliding
+		cfg.CMVNWindow = 50
+		cfg.CMVNCenter = center
+		cfg.CMVNNormVars = true
+		processor := newTestProcessor(t, cfg)
+
+		audio := noiseSignal(80000, 6)
+		want, err := processor.Process(audio)
+		if err != nil {
+			t.Fatalf("Process xatolik: %v", err)
+		}
+		got, err := extractAll(t, processor, cfg.SampleRate, audio, 1000)
+		if err != nil {
+			t.Fatalf("Extractor xatolik: %v", err)
+		}
+		assertSameFrames(t, got, want)
+	}
+}
+
+func TestExtractorResampling(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Padding = PaddingCenter
+	cfg.ResampleQuality = ResampleFast
+	processor := newTestProcessor(t, cfg)
+
+	audio := noiseSignal(22050, 3)
+	resampled, err := Resample(audio, 22050, cfg.SampleRate, ResampleFast)
+	if err != nil {
+		t.Fatalf("Resample xatolik: %v", err)
+	}
+	want, err := processor.Process(resampled)
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+	got, err := extractAll(t, processor, 22050, audio, 777)
+	if err != nil {
+		t.Fatalf("Extractor xatolik: %v", err)
+	}
+	assertSameFrames(t, got, want)
+}
+
+func TestExtractorErrors(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.CMVN = CMVNUtterance
+	if _, err := newTestProcessor(t, cfg).NewExtractor(cfg.SampleRate, func(int, []float32) error { return nil }); err == nil {
+		t.Error("utterance CMVN uchun xatolik kutilgan edi")
+	}
+
+	processor := newTestProcessor(t, DefaultConfig())
+	x, err := processor.NewExtractor(16000, func(int, []float32) error { return nil })
+	if err != nil {
+		t.Fatalf("NewExtractor xatolik: %v", err)
+	}
+	if err := x.Close(); err == nil {
+		t.Error("bo‘sh signal uchun xatolik kutilgan edi")
+	}
+
+	// Callback xatoligi o‘zgarishsiz qaytariladi va hisoblash to‘xtaydi
+	stop := errors.New("to‘xtash")
+	calls := 0
+	x, err = processor.NewExtractor(16000, func(int, []float32) error {
+		calls++
+		return stop
+	})
+	if err != nil {
+		t.Fatalf("NewExtractor xatolik: %v", err)
+	}
+	if err := x.Write(noiseSignal(16000, 1)); !errors.Is(err, stop) {
+		t.Errorf("callback xatoligi kutilgan edi, olindi %v", err)
+	}
+	if err := x.Close(); !errors.Is(err, stop) || calls != 1 {
+		t.Errorf("Close: %v, %d chaqiruv", err, calls)
+	}
+}
+
+func TestProcessFileWAV(t *testing.T) {
+	// 44.1 kHz stereo 16 bit, bir nechta o‘qish blokidan uzun
+	left, right := noiseSignal(100000, 1), noiseSignal(100000, 2)
+	data := make([]byte, 4*len(left))
+	for i := range left {
+		binary.LittleEndian.PutUint16(data[4*i:], uint16(int16(math.Round(float64(left[i])*32767))))
+		binary.LittleEndian.PutUint16(data[4*i+2:], uint16(int16(math.Round(float64(right[i])*32767))))
+	}
+	filename := filepath.Join(t.TempDir(), "long.wav")
+	if err := os.WriteFile(filename, buildWAV(wavSpec{tag: WAVFormatPCM, bits: 16, channels: 2}, data), 0o644); err != nil {
+		t.Fatalf("faylni yozishda xatolik: %v", err)
+	}
+
+	cfg := DefaultConfig()
+	cfg.DeltaOrder = 1
+	cfg.ResampleQuality = ResampleFast
+	processor := newTestProcessor(t, cfg)
+	audio, err := processor.LoadAudio(filename)
+	if err != nil {
+		t.Fatalf("LoadAudio xatolik: %v", err)
+	}
+	want, err := processor.Process(audio)
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+
+	var got [][]float32
+	err = processor.ProcessFile(filename, func(_ int, vector []float32) error {
+		got = append(got, vector)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("ProcessFile xatolik: %v", err)
+	}
+	assertSameFrames(t, got, want)
+}
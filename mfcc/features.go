@@ -0,0 +1,56 @@
+package mfcc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/BaxtiyorUrolov/go-mfcc/internal"
+)
+
+// FeatureSet ProcessFeatures hisoblaydigan xususiyatlarni tanlaydigan bit maskasi.
+// So‘ralmagan xususiyatlar hisoblanmaydi va FrameFeatures dagi mos maydonlar nol bo‘lib qoladi.
+type FeatureSet uint32
+
+const (
+	FeatureMFCC             FeatureSet = FeatureSet(internal.FeatureMFCC)             // MFCC (va DeltaOrder bo‘yicha deltalar)
+	FeatureZCR              FeatureSet = FeatureSet(internal.FeatureZCR)              // Zero-Crossing Rate
+	FeaturePitch            FeatureSet = FeatureSet(internal.FeaturePitch)            // Pitch, Voiced va VoicedProb
+	FeatureSpectralCentroid FeatureSet = FeatureSet(internal.FeatureSpectralCentroid) // Spectral Centroid
+	FeatureSpectralRollOff  FeatureSet = FeatureSet(internal.FeatureSpectralRollOff)  // Spectral Roll-off
+	FeatureEnergy           FeatureSet = FeatureSet(internal.FeatureEnergy)           // Ramka energiyasi (RMS)
+
+	// FeatureAll barcha xususiyatlar; Config.Features 0 bo‘lsa shu to‘plam ishlatiladi.
+	FeatureAll FeatureSet = FeatureSet(internal.FeatureAll)
+)
+
+// Has to‘plamda f dagi barcha xususiyatlar borligini tekshiradi.
+func (s FeatureSet) Has(f FeatureSet) bool {
+	return s&f == f
+}
+
+// orAll - 0 qiymatni barcha xususiyatlar sifatida talqin qilish
+func (s FeatureSet) orAll() FeatureSet {
+	if s == 0 {
+		return FeatureAll
+	}
+	return s
+}
+
+// FrameFeatures bitta ramkaning MFCC va qo‘shimcha xususiyatlari: ZCR, pitch va ovozlilik belgisi,
+// spektral centroid, roll-off va energiya.
+type FrameFeatures = internal.FrameFeatures
+
+// ProcessFeatures Process kabi hisoblaydi, lekin har bir ramka uchun Config.Features dagi xususiyatlarni qaytaradi.
+// Faqat vaqt sohasidagi xususiyatlar (ZCR, Energy, Pitch) so‘ralsa FFT va Mel bosqichlari bajarilmaydi.
+// Pitch PitchMethod bo‘yicha aniqlanadi; ovozsiz ramkalarda Pitch 0 va Voiced false. Natijani ExportToCSV ga uzatish mumkin.
+func (p *Processor) ProcessFeatures(audio []float32) ([]FrameFeatures, error) {
+	if len(audio) == 0 {
+		return nil, errors.New("bo‘sh audio kirishi")
+	}
+	features, err := p.proc.ProcessContext(context.Background(), audio)
+	if err != nil {
+		return nil, fmt.Errorf("xususiyatlarni hisoblashda xatolik: %w", err)
+	}
+	return features, nil
+}
@@ -0,0 +1,67 @@
+package mfcc
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestFeatureSelection(t *testing.T) {
+	audio := harmonicSignal(150, 16000, 16000)
+	cfg := DefaultConfig()
+	all, err := newTestProcessor(t, cfg).ProcessFeatures(audio)
+	if err != nil {
+		t.Fatalf("ProcessFeatures xatolik: %v", err)
+	}
+
+	sets := map[string]FeatureSet{
+		"vaqt":     FeatureZCR | FeatureEnergy,
+		"spektral": FeatureMFCC | FeatureSpectralCentroid,
+		"pitch":    FeaturePitch,
+	}
+	for name, set := range sets {
+		t.Run(name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Features = set
+			features, err := newTestProcessor(t, cfg).ProcessFeatures(audio)
+			if err != nil {
+				t.Fatalf("ProcessFeatures xatolik: %v", err)
+			}
+			if len(features) != len(all) {
+				t.Fatalf("ramkalar soni %d, kutilgan %d", len(features), len(all))
+			}
+			for i, f := range features {
+				want := all[i]
+				// So‘ralmagan maydonlar nol, so‘ralganlari to‘liq hisoblash bilan bir xil bo‘lishi kerak
+				if !set.Has(FeatureMFCC) {
+					want.MFCC = nil
+				}
+				if !set.Has(FeatureZCR) {
+					want.ZCR = 0
+				}
+				if !set.Has(FeaturePitch) {
+					want.Pitch, want.Voiced, want.VoicedProb = 0, false, 0
+				}
+				if !set.Has(FeatureSpectralCentroid) {
+					want.SpectralCentroid = 0
+				}
+				if !set.Has(FeatureSpectralRollOff) {
+					want.SpectralRollOff = 0
+				}
+				if !set.Has(FeatureEnergy) {
+					want.Energy = 0
+				}
+				if !reflect.DeepEqual(f, want) {
+					t.Fatalf("ramka %d: %+v, kutilgan %+v", i, f, want)
+				}
+			}
+		})
+	}
+}
+
+func TestFeatureSetValidation(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.Features = FeatureAll + 1
+	if err := cfg.Validate(); err == nil {
+		t.Error("noma’lum xususiyat biti uchun xatolik kutilgan edi")
+	}
+}
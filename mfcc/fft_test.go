@@ -0,0 +1,69 @@
+package mfcc
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/mjibson/go-dsp/fft"
+
+	"github.com/BaxtiyorUrolov/go-mfcc/internal"
+)
+
+// referencePowerSpectrum - go-dsp orqali complex128 da hisoblangan power spectrum (avvalgi yo‘l)
+func referencePowerSpectrum(frame []float32, buf []complex128) []float32 {
+	for i, v := range frame {
+		buf[i] = complex(float64(v), 0)
+	}
+	spectrum := fft.FFT(buf)
+	power := make([]float32, len(frame)/2+1)
+	for i := range power {
+		re, im := real(spectrum[i]), imag(spectrum[i])
+		power[i] = float32(re*re + im*im)
+	}
+	return power
+}
+
+func TestRealFFTMatchesReference(t *testing.T) {
+	// Ikki darajalari, aralash radix (400 = 4·4·25, 441 = 3²·7²), toq va tub uzunliklar
+	for _, n := range []int{1, 2, 3, 4, 6, 9, 12, 97, 256, 400, 441, 512, 1000, 1024} {
+		frame := noiseSignal(n, int64(n))
+		want := referencePowerSpectrum(frame, make([]complex128, n))
+
+		plan := internal.NewRealFFT(n)
+		got := make([]float32, n/2+1)
+		plan.PowerSpectrum(frame, got, make([]complex64, plan.BufferLen()))
+
+		peak := 0.0
+		for _, v := range want {
+			peak = math.Max(peak, float64(v))
+		}
+		for k := range want {
+			if diff := math.Abs(float64(got[k] - want[k])); diff > 1e-5*peak+1e-6 {
+				t.Fatalf("n=%d, bin %d: %v, kutilgan %v", n, k, got[k], want[k])
+			}
+		}
+	}
+}
+
+func BenchmarkPowerSpectrum(b *testing.B) {
+	for _, n := range []int{400, 512} {
+		frame := noiseSignal(n, 1)
+		b.Run(fmt.Sprintf("go-dsp/%d", n), func(b *testing.B) {
+			buf := make([]complex128, n)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				referencePowerSpectrum(frame, buf)
+			}
+		})
+		b.Run(fmt.Sprintf("float32/%d", n), func(b *testing.B) {
+			plan := internal.NewRealFFT(n)
+			buf := make([]complex64, plan.BufferLen())
+			out := make([]float32, n/2+1)
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				plan.PowerSpectrum(frame, out, buf)
+			}
+		})
+	}
+}
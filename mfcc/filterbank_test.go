@@ -0,0 +1,130 @@
+package mfcc
+
+import (
+	"math"
+	"testing"
+
+	"github.com/BaxtiyorUrolov/go-mfcc/internal"
+)
+
+// referenceMelFilters - Zich filtrlar bo‘yicha Mel energiyalari (avvalgi yo‘l: har bir filtr butun FFT kengligida)
+func referenceMelFilters(power []float32, filterBanks [][]float32, out []float32) []float32 {
+	for i, filter := range filterBanks {
+		var energy float32
+		for j, w := range filter {
+			energy += w * power[j]
+		}
+		out[i] = energy
+	}
+	return out
+}
+
+// referenceDCT - Har bir ramkada math.Cos chaqiradigan DCT-II (avvalgi yo‘l)
+func referenceDCT(logMel []float32, numCoeffs int, out []float32) []float32 {
+	n := len(logMel)
+	for k := 0; k < numCoeffs; k++ {
+		var sum float32
+		for m, v := range logMel {
+			sum += v * float32(math.Cos(math.Pi*float64(k)*(float64(m)+0.5)/float64(n)))
+		}
+		if k == 0 {
+			out[k] = sum * float32(math.Sqrt(1/float64(n)))
+		} else {
+			out[k] = sum * float32(math.Sqrt(2/float64(n)))
+		}
+	}
+	return out
+}
+
+// benchFilterBank - Standart konfiguratsiyaning zich filtrlar banki
+func benchFilterBank(tb testing.TB, cfg Config) [][]float32 {
+	tb.Helper()
+	processor, err := NewProcessor(cfg)
+	if err != nil {
+		tb.Fatalf("NewProcessor xatolik: %v", err)
+	}
+	defer processor.Close()
+	return processor.MelFilterBank()
+}
+
+func TestSparseFiltersMatchDense(t *testing.T) {
+	configs := map[string]func(*Config){
+		"htk":    func(c *Config) {},
+		"slaney": func(c *Config) { c.MelScale, c.MelNorm, c.FFTSize = MelScaleSlaney, MelNormSlaney, 2048 },
+		"kaldi":  func(c *Config) { c.MelScale, c.FFTSize, c.NumFilters = MelScaleKaldi, 512, 23 },
+	}
+	for name, configure := range configs {
+		t.Run(name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			configure(&cfg)
+			dense := benchFilterBank(t, cfg)
+			sparse := internal.NewMelFilterBank(dense)
+			dct := internal.NewDCTMatrix(len(dense), cfg.NumCoefficients)
+
+			nonzero := 0
+			for _, f := range sparse {
+				nonzero += len(f.Weights)
+			}
+			if nonzero >= len(dense)*len(dense[0])/2 {
+				t.Errorf("siyrak filtrlar %d og‘irlik saqlaydi, zich %d", nonzero, len(dense)*len(dense[0]))
+			}
+
+			power := noiseSignal(len(dense[0]), 7)
+			for i := range power {
+				power[i] *= power[i]
+			}
+			want := referenceMelFilters(power, dense, make([]float32, len(dense)))
+			got := sparse.Apply(power, make([]float32, len(dense)))
+			for i := range want {
+				if got[i] != want[i] {
+					t.Fatalf("filtr %d: %v, kutilgan %v", i, got[i], want[i])
+				}
+			}
+
+			wantDCT := referenceDCT(want, cfg.NumCoefficients, make([]float32, cfg.NumCoefficients))
+			gotDCT := dct.Apply(want, make([]float32, cfg.NumCoefficients))
+			for k := range wantDCT {
+				if gotDCT[k] != wantDCT[k] {
+					t.Fatalf("koeffitsient %d: %v, kutilgan %v", k, gotDCT[k], wantDCT[k])
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMelFilters(b *testing.B) {
+	cfg := DefaultConfig()
+	dense := benchFilterBank(b, cfg)
+	sparse := internal.NewMelFilterBank(dense)
+	power := noiseSignal(len(dense[0]), 1)
+	out := make([]float32, len(dense))
+
+	b.Run("dense", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			referenceMelFilters(power, dense, out)
+		}
+	})
+	b.Run("sparse", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			sparse.Apply(power, out)
+		}
+	})
+}
+
+func BenchmarkDCT(b *testing.B) {
+	cfg := DefaultConfig()
+	logMel := noiseSignal(cfg.NumFilters, 1)
+	dct := internal.NewDCTMatrix(cfg.NumFilters, cfg.NumCoefficients)
+	out := make([]float32, cfg.NumCoefficients)
+
+	b.Run("cos", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			referenceDCT(logMel, cfg.NumCoefficients, out)
+		}
+	})
+	b.Run("matrix", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			dct.Apply(logMel, out)
+		}
+	})
+}
@@ -0,0 +1,751 @@
+package mfcc
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/md5"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"io"
+	"math/bits"
+)
+
+// ErrInvalidFLAC - Ma’lumotlar FLAC oqimi tuzilmasiga mos kelmaydi (sarlavha, CRC yoki subframe xatoligi)
+var ErrInvalidFLAC = errors.New("noto‘g‘ri FLAC ma’lumotlari")
+
+// ErrFLACMD5Mismatch - Dekodlangan namunalarning MD5 yig‘indisi STREAMINFO dagi qiymatga mos kelmadi
+var ErrFLACMD5Mismatch = errors.New("FLAC MD5 nazorat yig‘indisi mos kelmadi")
+
+// FLACStreamInfo - FLAC faylning STREAMINFO metama’lumotlari
+type FLACStreamInfo struct {
+	MinBlockSize  int      // Eng kichik blok o‘lchami (namunalar)
+	MaxBlockSize  int      // Eng katta blok o‘lchami (namunalar)
+	MinFrameSize  int      // Eng kichik ramka o‘lchami (bayt, 0 - noma’lum)
+	MaxFrameSize  int      // Eng katta ramka o‘lchami (bayt, 0 - noma’lum)
+	SampleRate    int      // Namunalar tezligi (Hz)
+	Channels      int      // Kanallar soni (1-8)
+	BitsPerSample int      // Namuna bit chuqurligi (4-32)
+	TotalSamples  int64    // Har bir kanaldagi namunalar soni (0 - noma’lum)
+	MD5           [16]byte // Dekodlangan namunalarning MD5 yig‘indisi (nollar - hisoblanmagan)
+}
+
+// FLACOptions FLAC dekodlash sozlamalari.
+type FLACOptions struct {
+	VerifyMD5 bool // Dekodlangan namunalarni STREAMINFO dagi MD5 bilan solishtirish (sekinroq)
+}
+
+// FLAC ramka sarlavhasidagi kanal juftlash rejimlari (0-7 mustaqil kanallar)
+const (
+	flacLeftSide  = 8
+	flacSideRight = 9
+	flacMidSide   = 10
+)
+
+// flacFixedSampleRates - Ramka sarlavhasidagi 1-11 tezlik kodlari
+var flacFixedSampleRates = [...]int{0, 88200, 176400, 192000, 8000, 16000, 22050, 24000, 32000, 44100, 48000, 96000}
+
+// flacSampleSizes - Ramka sarlavhasidagi bit chuqurligi kodlari (0 - STREAMINFO dan, -1 - zahiralangan)
+var flacSampleSizes = [...]int{0, 8, 12, -1, 16, 20, 24, 32}
+
+var (
+	flacCRC8Table  = makeFLACCRC8Table()
+	flacCRC16Table = makeFLACCRC16Table()
+)
+
+// DecodeFLAC r dan FLAC oqimini o‘qiydi va har bir kanal namunalarini [-1, 1] oralig‘ida qaytaradi ([kanal][namuna]).
+// CONSTANT, VERBATIM, FIXED va LPC subframe’lari, barcha stereo juftlash rejimlari va Rice/Rice2 qoldiqlari
+// qo‘llab-quvvatlanadi. Fayl boshidagi ID3v2 tegi o‘tkazib yuboriladi. Ramka CRC lari doim tekshiriladi;
+// MD5 tekshiruvi uchun DecodeFLACWithOptions dan foydalaning.
+func DecodeFLAC(r io.Reader) ([][]float32, FLACStreamInfo, error) {
+	return DecodeFLACWithOptions(r, FLACOptions{})
+}
+
+// DecodeFLACWithOptions DecodeFLAC kabi ishlaydi, lekin FLACOptions bo‘yicha qo‘shimcha tekshiruvlarni bajaradi.
+func DecodeFLACWithOptions(r io.Reader, opts FLACOptions) ([][]float32, FLACStreamInfo, error) {
+	br, ok := r.(flacByteReader)
+	if !ok {
+		br = bufio.NewReader(r)
+	}
+	info, err := readFLACMetadata(br)
+	if err != nil {
+		return nil, info, err
+	}
+
+	var digest hash.Hash
+	if opts.VerifyMD5 && info.MD5 != [16]byte{} {
+		digest = md5.New()
+	}
+
+	channels := make([][]float32, info.Channels)
+	if info.TotalSamples > 0 && info.TotalSamples <= 1<<28 {
+		for c := range channels {
+			channels[c] = make([]float32, 0, info.TotalSamples)
+		}
+	}
+	dec := &flacDecoder{bits: flacBitReader{r: br}, info: info}
+	for info.TotalSamples == 0 || int64(len(channels[0])) < info.TotalSamples {
+		frame, bps, err := dec.decodeFrame()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, info, fmt.Errorf("%w: ramka %d: %v", ErrInvalidFLAC, dec.frames, err)
+		}
+		full := float64(uint64(1) << (bps - 1))
+		for c, samples := range frame {
+			for _, v := range samples {
+				channels[c] = append(channels[c], float32(float64(v)/full))
+			}
+		}
+		if digest != nil {
+			writeFLACMD5(digest, frame, bps)
+		}
+		dec.frames++
+	}
+
+	if info.TotalSamples > 0 && int64(len(channels[0])) != info.TotalSamples {
+		return nil, info, fmt.Errorf("%w: %d namuna dekodlandi, STREAMINFO da %d", ErrInvalidFLAC, len(channels[0]), info.TotalSamples)
+	}
+	if digest != nil && !bytes.Equal(digest.Sum(nil), info.MD5[:]) {
+		return nil, info, ErrFLACMD5Mismatch
+	}
+	return channels, info, nil
+}
+
+// flacByteReader - Ham bayt-bayt, ham blok bo‘lib o‘qiy oladigan manba
+type flacByteReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// readFLACMetadata - "fLaC" belgisi va metama’lumot bloklarini o‘qish (STREAMINFO dan boshqalari o‘tkazib yuboriladi)
+func readFLACMetadata(r flacByteReader) (FLACStreamInfo, error) {
+	var info FLACStreamInfo
+	var magic [4]byte
+	if _, err := io.ReadFull(r, magic[:]); err != nil {
+		return info, fmt.Errorf("%w: sarlavhani o‘qib bo‘lmadi: %v", ErrInvalidFLAC, err)
+	}
+	if string(magic[:3]) == "ID3" {
+		if err := skipID3v2(r); err != nil {
+			return info, err
+		}
+		if _, err := io.ReadFull(r, magic[:]); err != nil {
+			return info, fmt.Errorf("%w: ID3 tegidan keyin sarlavhani o‘qib bo‘lmadi: %v", ErrInvalidFLAC, err)
+		}
+	}
+	if string(magic[:]) != "fLaC" {
+		return info, fmt.Errorf("%w: fLaC belgisi topilmadi", ErrInvalidFLAC)
+	}
+
+	haveInfo := false
+	for last := false; !last; {
+		var header [4]byte
+		if _, err := io.ReadFull(r, header[:]); err != nil {
+			return info, fmt.Errorf("%w: metama’lumot sarlavhasini o‘qib bo‘lmadi: %v", ErrInvalidFLAC, err)
+		}
+		last = header[0]&0x80 != 0
+		blockType := header[0] & 0x7F
+		size := int64(header[1])<<16 | int64(header[2])<<8 | int64(header[3])
+
+		switch {
+		case blockType == 0:
+			if haveInfo || size != 34 {
+				return info, fmt.Errorf("%w: STREAMINFO bloki noto‘g‘ri (%d bayt)", ErrInvalidFLAC, size)
+			}
+			var body [34]byte
+			if _, err := io.ReadFull(r, body[:]); err != nil {
+				return info, fmt.Errorf("%w: STREAMINFO ni o‘qib bo‘lmadi: %v", ErrInvalidFLAC, err)
+			}
+			info = parseFLACStreamInfo(body)
+			haveInfo = true
+		case !haveInfo:
+			return info, fmt.Errorf("%w: birinchi metama’lumot bloki STREAMINFO emas", ErrInvalidFLAC)
+		case blockType == 127:
+			return info, fmt.Errorf("%w: zahiralangan metama’lumot turi 127", ErrInvalidFLAC)
+		default:
+			// SEEKTABLE, VORBIS_COMMENT, PICTURE, PADDING va h.k. MFCC uchun kerak emas
+			if _, err := io.CopyN(io.Discard, r, size); err != nil {
+				return info, fmt.Errorf("%w: %d-turdagi metama’lumot blokini o‘tkazib bo‘lmadi: %v", ErrInvalidFLAC, blockType, err)
+			}
+		}
+	}
+
+	if info.SampleRate == 0 || info.BitsPerSample < 4 || info.MaxBlockSize < info.MinBlockSize {
+		return info, fmt.Errorf("%w: STREAMINFO: %d Hz, %d bit, blok %d-%d", ErrInvalidFLAC,
+			info.SampleRate, info.BitsPerSample, info.MinBlockSize, info.MaxBlockSize)
+	}
+	return info, nil
+}
+
+// parseFLACStreamInfo - 34 baytli STREAMINFO blokini tahlil qilish
+func parseFLACStreamInfo(body [34]byte) FLACStreamInfo {
+	be := binary.BigEndian
+	packed := be.Uint64(body[10:18]) // 20 bit tezlik, 3 bit kanallar-1, 5 bit bitlar-1, 36 bit namunalar
+	info := FLACStreamInfo{
+		MinBlockSize:  int(be.Uint16(body[0:2])),
+		MaxBlockSize:  int(be.Uint16(body[2:4])),
+		MinFrameSize:  int(body[4])<<16 | int(body[5])<<8 | int(body[6]),
+		MaxFrameSize:  int(body[7])<<16 | int(body[8])<<8 | int(body[9]),
+		SampleRate:    int(packed >> 44),
+		Channels:      int(packed>>41&0x7) + 1,
+		BitsPerSample: int(packed>>36&0x1F) + 1,
+		TotalSamples:  int64(packed & (1<<36 - 1)),
+	}
+	copy(info.MD5[:], body[18:34])
+	return info
+}
+
+// skipID3v2 - Fayl boshidagi ID3v2 tegini o‘tkazib yuborish ("ID3" va versiya bayti allaqachon o‘qilgan)
+func skipID3v2(r io.Reader) error {
+	var rest [6]byte
+	if _, err := io.ReadFull(r, rest[:]); err != nil {
+		return fmt.Errorf("%w: ID3 tegini o‘qib bo‘lmadi: %v", ErrInvalidFLAC, err)
+	}
+	// O‘lcham 4 ta 7 bitli "syncsafe" baytda; footer bayrog‘i bo‘lsa yana 10 bayt
+	size := int64(rest[2])<<21 | int64(rest[3])<<14 | int64(rest[4])<<7 | int64(rest[5])
+	if rest[1]&0x10 != 0 {
+		size += 10
+	}
+	if _, err := io.CopyN(io.Discard, r, size); err != nil {
+		return fmt.Errorf("%w: ID3 tegini o‘tkazib bo‘lmadi: %v", ErrInvalidFLAC, err)
+	}
+	return nil
+}
+
+// flacDecoder - Audio ramkalarini ketma-ket dekodlovchi holat
+type flacDecoder struct {
+	bits    flacBitReader
+	info    FLACStreamInfo
+	frames  int       // Dekodlangan ramkalar soni (xatolik matnlari uchun)
+	samples [][]int64 // Kanallar bo‘yicha ramka buferlari (qayta ishlatiladi)
+	coeffs  [32]int64 // LPC koeffitsientlari
+}
+
+// flacFrameHeader - Ramka sarlavhasidan o‘qilgan parametrlar
+type flacFrameHeader struct {
+	blockSize     int
+	sampleRate    int
+	assignment    int // 0-7 - mustaqil kanallar (soni assignment+1), 8-10 - stereo juftlash
+	bitsPerSample int
+}
+
+// channels - Ramkadagi kanallar soni
+func (h flacFrameHeader) channels() int {
+	if h.assignment >= flacLeftSide {
+		return 2
+	}
+	return h.assignment + 1
+}
+
+// decodeFrame - Bitta audio ramkani dekodlash; natija [kanal][namuna] butun sonlar va bit chuqurligi.
+// Oqim ramkalar chegarasida tugasa io.EOF qaytariladi.
+func (d *flacDecoder) decodeFrame() ([][]int64, int, error) {
+	header, err := d.readFrameHeader()
+	if err != nil {
+		return nil, 0, err
+	}
+	numChannels := header.channels()
+	if numChannels != d.info.Channels {
+		return nil, 0, fmt.Errorf("ramkada %d kanal, STREAMINFO da %d", numChannels, d.info.Channels)
+	}
+	if d.info.MaxBlockSize > 0 && header.blockSize > d.info.MaxBlockSize {
+		return nil, 0, fmt.Errorf("blok o‘lchami %d > %d", header.blockSize, d.info.MaxBlockSize)
+	}
+
+	if len(d.samples) != numChannels {
+		d.samples = make([][]int64, numChannels)
+	}
+	for c := range d.samples {
+		if cap(d.samples[c]) < header.blockSize {
+			d.samples[c] = make([]int64, header.blockSize)
+		}
+		d.samples[c] = d.samples[c][:header.blockSize]
+
+		// Side kanali bir bit kengroq
+		bps := header.bitsPerSample
+		if c == 1 && (header.assignment == flacLeftSide || header.assignment == flacMidSide) ||
+			c == 0 && header.assignment == flacSideRight {
+			bps++
+		}
+		if err := d.decodeSubframe(d.samples[c], bps); err != nil {
+			return nil, 0, fmt.Errorf("subframe %d: %w", c, err)
+		}
+	}
+
+	// Ramka oxiri: bayt chegarasigacha to‘ldiruvchi bitlar va butun ramka bo‘yicha CRC-16
+	d.bits.align()
+	want := d.bits.crc16
+	got, err := d.bits.read(16)
+	if err != nil {
+		return nil, 0, unexpectedEOF(err)
+	}
+	if uint16(got) != want {
+		return nil, 0, fmt.Errorf("CRC-16 mos kelmadi: 0x%04X, hisoblangan 0x%04X", got, want)
+	}
+
+	decorrelateFLAC(d.samples, header.assignment)
+	return d.samples, header.bitsPerSample, nil
+}
+
+// readFrameHeader - Sinxronizatsiya kodi, ramka parametrlari va CRC-8 ni o‘qish
+func (d *flacDecoder) readFrameHeader() (flacFrameHeader, error) {
+	var h flacFrameHeader
+	br := &d.bits
+	br.crc8, br.crc16 = 0, 0
+
+	sync, err := br.read(8)
+	if err != nil {
+		return h, err // Ramkalar orasida io.EOF - oqim oxiri
+	}
+	fields, err := br.read(24)
+	if err != nil {
+		return h, unexpectedEOF(err)
+	}
+	// 15 bit sinxronizatsiya kodi (0x7FFC) va blocking strategy biti
+	if sync != 0xFF || fields>>17 != 0x7C {
+		return h, fmt.Errorf("sinxronizatsiya kodi topilmadi")
+	}
+	blockCode := int(fields >> 12 & 0xF)
+	rateCode := int(fields >> 8 & 0xF)
+	h.assignment = int(fields >> 4 & 0xF)
+	sizeCode := int(fields >> 1 & 0x7)
+	if fields&1 != 0 || h.assignment > flacMidSide || flacSampleSizes[sizeCode] < 0 || blockCode == 0 || rateCode == 15 {
+		return h, fmt.Errorf("zahiralangan sarlavha qiymati")
+	}
+
+	// Ramka yoki namuna raqami (UTF-8 ga o‘xshash kodlash) - faqat o‘tkazib yuboriladi
+	if err := br.skipUTF8Number(); err != nil {
+		return h, err
+	}
+
+	switch {
+	case blockCode == 1:
+		h.blockSize = 192
+	case blockCode <= 5:
+		h.blockSize = 576 << (blockCode - 2)
+	case blockCode <= 7:
+		n, err := br.read(uint(8 << (blockCode - 6)))
+		if err != nil {
+			return h, unexpectedEOF(err)
+		}
+		h.blockSize = int(n) + 1
+	default:
+		h.blockSize = 256 << (blockCode - 8)
+	}
+
+	switch {
+	case rateCode == 0:
+		h.sampleRate = d.info.SampleRate
+	case rateCode <= 11:
+		h.sampleRate = flacFixedSampleRates[rateCode]
+	default:
+		n, err := br.read(uint(8 << min(rateCode-12, 1)))
+		if err != nil {
+			return h, unexpectedEOF(err)
+		}
+		h.sampleRate = int(n) * [...]int{1000, 1, 10}[rateCode-12]
+	}
+
+	h.bitsPerSample = flacSampleSizes[sizeCode]
+	if h.bitsPerSample == 0 {
+		h.bitsPerSample = d.info.BitsPerSample
+	}
+
+	want := br.crc8
+	got, err := br.read(8)
+	if err != nil {
+		return h, unexpectedEOF(err)
+	}
+	if uint8(got) != want {
+		return h, fmt.Errorf("sarlavha CRC-8 mos kelmadi: 0x%02X, hisoblangan 0x%02X", got, want)
+	}
+	if h.sampleRate != d.info.SampleRate || h.bitsPerSample != d.info.BitsPerSample {
+		return h, fmt.Errorf("ramka formati (%d Hz, %d bit) STREAMINFO ga mos kelmaydi", h.sampleRate, h.bitsPerSample)
+	}
+	return h, nil
+}
+
+// decodeSubframe - Bitta kanal subframe’ini out ga dekodlash (bps - shu subframe bit chuqurligi)
+func (d *flacDecoder) decodeSubframe(out []int64, bps int) error {
+	br := &d.bits
+	header, err := br.read(8)
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	if header&0x80 != 0 {
+		return errors.New("subframe to‘ldiruvchi biti nol emas")
+	}
+	kind := int(header >> 1 & 0x3F)
+
+	// Wasted bits: barcha namunalar 2^k ga bo‘linadi, k unar kodda
+	wasted := 0
+	if header&1 != 0 {
+		k, err := br.unary()
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		wasted = int(k) + 1
+	}
+	bps -= wasted
+	if bps <= 0 {
+		return fmt.Errorf("wasted bits (%d) bit chuqurligidan katta", wasted)
+	}
+
+	switch {
+	case kind == 0: // CONSTANT
+		v, err := br.readSigned(uint(bps))
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		for i := range out {
+			out[i] = v
+		}
+	case kind == 1: // VERBATIM
+		if err := br.readSignedInto(out, uint(bps)); err != nil {
+			return unexpectedEOF(err)
+		}
+	case kind >= 8 && kind <= 12: // FIXED, tartib 0-4
+		if err := d.decodeFixed(out, kind-8, bps); err != nil {
+			return err
+		}
+	case kind >= 32: // LPC, tartib 1-32
+		if err := d.decodeLPC(out, kind-31, bps); err != nil {
+			return err
+		}
+	default:
+		return fmt.Errorf("zahiralangan subframe turi %d", kind)
+	}
+
+	if wasted > 0 {
+		for i := range out {
+			out[i] <<= wasted
+		}
+	}
+	return nil
+}
+
+// decodeFixed - FIXED subframe: boshlang‘ich namunalar, qoldiq va qat’iy polinomial bashorat
+func (d *flacDecoder) decodeFixed(out []int64, order, bps int) error {
+	if order > len(out) {
+		return fmt.Errorf("bashorat tartibi %d blok o‘lchamidan katta", order)
+	}
+	if err := d.bits.readSignedInto(out[:order], uint(bps)); err != nil {
+		return unexpectedEOF(err)
+	}
+	if err := d.decodeResidual(out, order); err != nil {
+		return err
+	}
+	for i := order; i < len(out); i++ {
+		switch order {
+		case 1:
+			out[i] += out[i-1]
+		case 2:
+			out[i] += 2*out[i-1] - out[i-2]
+		case 3:
+			out[i] += 3*out[i-1] - 3*out[i-2] + out[i-3]
+		case 4:
+			out[i] += 4*out[i-1] - 6*out[i-2] + 4*out[i-3] - out[i-4]
+		}
+	}
+	return nil
+}
+
+// decodeLPC - LPC subframe: boshlang‘ich namunalar, kvantlangan koeffitsientlar, qoldiq va chiziqli bashorat
+func (d *flacDecoder) decodeLPC(out []int64, order, bps int) error {
+	br := &d.bits
+	if order > len(out) {
+		return fmt.Errorf("bashorat tartibi %d blok o‘lchamidan katta", order)
+	}
+	if err := br.readSignedInto(out[:order], uint(bps)); err != nil {
+		return unexpectedEOF(err)
+	}
+	precision, err := br.read(4)
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	if precision == 15 {
+		return errors.New("LPC koeffitsient aniqligi zahiralangan qiymatda")
+	}
+	shift, err := br.readSigned(5)
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	if shift < 0 {
+		return fmt.Errorf("manfiy LPC siljishi %d", shift)
+	}
+	coeffs := d.coeffs[:order]
+	if err := br.readSignedInto(coeffs, uint(precision+1)); err != nil {
+		return unexpectedEOF(err)
+	}
+	if err := d.decodeResidual(out, order); err != nil {
+		return err
+	}
+
+	// coeffs[0] eng yaqin (i-1) namunaga ko‘paytiriladi
+	for i := order; i < len(out); i++ {
+		var sum int64
+		history := out[i-order : i]
+		for j, c := range coeffs {
+			sum += c * history[order-1-j]
+		}
+		out[i] += sum >> shift
+	}
+	return nil
+}
+
+// decodeResidual - Rice/Rice2 kodlangan qoldiqni out[order:] ga o‘qish (bo‘limlar va escape bilan)
+func (d *flacDecoder) decodeResidual(out []int64, order int) error {
+	br := &d.bits
+	method, err := br.read(2)
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	if method > 1 {
+		return fmt.Errorf("zahiralangan qoldiq kodlash usuli %d", method)
+	}
+	paramBits := uint(4 + method)
+	escape := uint64(1)<<paramBits - 1
+
+	partitionOrder, err := br.read(4)
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	partitionSize := len(out) >> partitionOrder
+	if partitionSize<<partitionOrder != len(out) || partitionSize < order {
+		return fmt.Errorf("bo‘lim tartibi %d blok o‘lchami %d ga mos emas", partitionOrder, len(out))
+	}
+
+	i := order
+	for end := partitionSize; end <= len(out); end += partitionSize {
+		param, err := br.read(paramBits)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		if param == escape {
+			// Escape: bo‘lim namunalari belgilangan kenglikdagi xom ishorali sonlar
+			width, err := br.read(5)
+			if err != nil {
+				return unexpectedEOF(err)
+			}
+			if err := br.readSignedInto(out[i:end], uint(width)); err != nil {
+				return unexpectedEOF(err)
+			}
+			i = end
+			continue
+		}
+		for ; i < end; i++ {
+			v, err := br.rice(uint(param))
+			if err != nil {
+				return unexpectedEOF(err)
+			}
+			out[i] = v
+		}
+	}
+	return nil
+}
+
+// decorrelateFLAC - Stereo juftlash rejimlarida chap/o‘ng kanallarni tiklash
+func decorrelateFLAC(samples [][]int64, assignment int) {
+	switch assignment {
+	case flacLeftSide:
+		left, side := samples[0], samples[1]
+		for i := range side {
+			side[i] = left[i] - side[i]
+		}
+	case flacSideRight:
+		side, right := samples[0], samples[1]
+		for i := range side {
+			side[i] += right[i]
+		}
+	case flacMidSide:
+		mid, side := samples[0], samples[1]
+		for i := range mid {
+			m := mid[i]<<1 | side[i]&1
+			mid[i], side[i] = (m+side[i])>>1, (m-side[i])>>1
+		}
+	}
+}
+
+// writeFLACMD5 - Ramka namunalarini libFLAC kabi (interleaved, little-endian, butun baytlar) MD5 ga yozish
+func writeFLACMD5(digest hash.Hash, frame [][]int64, bps int) {
+	width := (bps + 7) / 8
+	buf := make([]byte, 0, len(frame)*len(frame[0])*width)
+	for i := range frame[0] {
+		for _, samples := range frame {
+			v := samples[i]
+			for b := 0; b < width; b++ {
+				buf = append(buf, byte(v>>(8*b)))
+			}
+		}
+	}
+	digest.Write(buf)
+}
+
+// unexpectedEOF - Ramka o‘rtasida tugagan oqim uchun io.EOF ni io.ErrUnexpectedEOF ga almashtirish
+func unexpectedEOF(err error) error {
+	if errors.Is(err, io.EOF) {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
+
+// flacBitReader - MSB-first bit o‘quvchi; o‘qilgan har bir bayt bo‘yicha CRC-8 va CRC-16 ni yangilaydi
+type flacBitReader struct {
+	r     io.ByteReader
+	cache uint64 // Hali o‘qilmagan bitlar (kichik n biti)
+	n     uint   // cache dagi bitlar soni (har doim < 8 o‘qishlar orasida)
+	crc8  uint8
+	crc16 uint16
+}
+
+// fill - cache da kamida k bit bo‘lguncha baytlarni o‘qish
+func (br *flacBitReader) fill(k uint) error {
+	for br.n < k {
+		b, err := br.r.ReadByte()
+		if err != nil {
+			return err
+		}
+		br.crc8 = flacCRC8Table[br.crc8^b]
+		br.crc16 = br.crc16<<8 ^ flacCRC16Table[byte(br.crc16>>8)^b]
+		br.cache = br.cache<<8 | uint64(b)
+		br.n += 8
+	}
+	return nil
+}
+
+// read - k bitni (k <= 56) ishorasiz son sifatida o‘qish
+func (br *flacBitReader) read(k uint) (uint64, error) {
+	if k == 0 {
+		return 0, nil
+	}
+	if err := br.fill(k); err != nil {
+		return 0, err
+	}
+	br.n -= k
+	v := br.cache >> br.n & (1<<k - 1)
+	br.cache &= 1<<br.n - 1
+	return v, nil
+}
+
+// readSigned - k bitli ikkilik to‘ldiruvchi (two's complement) sonni o‘qish
+func (br *flacBitReader) readSigned(k uint) (int64, error) {
+	v, err := br.read(k)
+	if err != nil || k == 0 {
+		return 0, err
+	}
+	return int64(v<<(64-k)) >> (64 - k), nil
+}
+
+// readSignedInto - out ning har bir elementiga k bitli ishorali son o‘qish
+func (br *flacBitReader) readSignedInto(out []int64, k uint) error {
+	for i := range out {
+		v, err := br.readSigned(k)
+		if err != nil {
+			return err
+		}
+		out[i] = v
+	}
+	return nil
+}
+
+// unary - Birinchi 1 bitgacha bo‘lgan nollar sonini o‘qish
+func (br *flacBitReader) unary() (uint64, error) {
+	var q uint64
+	for {
+		if br.n == 0 {
+			if err := br.fill(8); err != nil {
+				return 0, err
+			}
+		}
+		if br.cache == 0 {
+			q += uint64(br.n)
+			br.n = 0
+			continue
+		}
+		length := uint(bits.Len64(br.cache))
+		q += uint64(br.n - length)
+		br.n = length - 1
+		br.cache &= 1<<br.n - 1
+		return q, nil
+	}
+}
+
+// rice - Rice parametri k bo‘lgan bitta qoldiqni o‘qish (zigzag orqali ishorali songa)
+func (br *flacBitReader) rice(k uint) (int64, error) {
+	q, err := br.unary()
+	if err != nil {
+		return 0, err
+	}
+	low, err := br.read(k)
+	if err != nil {
+		return 0, err
+	}
+	u := q<<k | low
+	return int64(u>>1) ^ -int64(u&1), nil
+}
+
+// align - Joriy baytning qolgan bitlarini tashlab, bayt chegarasiga o‘tish
+func (br *flacBitReader) align() {
+	br.n = 0
+	br.cache = 0
+}
+
+// skipUTF8Number - Ramka sarlavhasidagi UTF-8 ga o‘xshash kodlangan raqamni o‘tkazib yuborish
+func (br *flacBitReader) skipUTF8Number() error {
+	lead, err := br.read(8)
+	if err != nil {
+		return unexpectedEOF(err)
+	}
+	extra := bits.LeadingZeros8(^uint8(lead)) - 1
+	if lead < 0x80 {
+		extra = 0
+	} else if extra < 1 || extra > 6 {
+		return fmt.Errorf("noto‘g‘ri kodlangan ramka raqami 0x%02X", lead)
+	}
+	for ; extra > 0; extra-- {
+		b, err := br.read(8)
+		if err != nil {
+			return unexpectedEOF(err)
+		}
+		if b&0xC0 != 0x80 {
+			return fmt.Errorf("noto‘g‘ri kodlangan ramka raqami")
+		}
+	}
+	return nil
+}
+
+// makeFLACCRC8Table - CRC-8 jadvali (polinom x^8 + x^2 + x + 1)
+func makeFLACCRC8Table() [256]uint8 {
+	var table [256]uint8
+	for i := range table {
+		crc := uint8(i)
+		for b := 0; b < 8; b++ {
+			if crc&0x80 != 0 {
+				crc = crc<<1 ^ 0x07
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return table
+}
+
+// makeFLACCRC16Table - CRC-16 jadvali (polinom x^16 + x^15 + x^2 + 1)
+func makeFLACCRC16Table() [256]uint16 {
+	var table [256]uint16
+	for i := range table {
+		crc := uint16(i) << 8
+		for b := 0; b < 8; b++ {
+			if crc&0x8000 != 0 {
+				crc = crc<<1 ^ 0x8005
+			} else {
+				crc <<= 1
+			}
+		}
+		table[i] = crc
+	}
+	return table
+}
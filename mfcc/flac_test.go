@@ -0,0 +1,176 @@
+package mfcc
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// flacFixtures - testdata/gen_flac_fixtures.py yaratgan FLAC/WAV juftliklari
+var flacFixtures = []struct {
+	name       string
+	sampleRate int
+	channels   int
+	bits       int
+}{
+	{"mono16", 16000, 1, 16},
+	{"stereo16", 44100, 2, 16},
+	{"stereo24", 12000, 2, 24},
+}
+
+// readFixture - testdata dagi faylni o‘qish
+func readFixture(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("testdata", name))
+	if err != nil {
+		t.Fatalf("%s faylini o‘qishda xatolik: %v", name, err)
+	}
+	return data
+}
+
+func TestDecodeFLACFixtures(t *testing.T) {
+	for _, fx := range flacFixtures {
+		t.Run(fx.name, func(t *testing.T) {
+			got, info, err := DecodeFLACWithOptions(bytes.NewReader(readFixture(t, fx.name+".flac")), FLACOptions{VerifyMD5: true})
+			if err != nil {
+				t.Fatalf("DecodeFLAC xatolik: %v", err)
+			}
+			if info.SampleRate != fx.sampleRate || info.Channels != fx.channels || info.BitsPerSample != fx.bits {
+				t.Errorf("kutilmagan STREAMINFO: %+v", info)
+			}
+			want, _, err := DecodeWAV(bytes.NewReader(readFixture(t, fx.name+".wav")))
+			if err != nil {
+				t.Fatalf("DecodeWAV xatolik: %v", err)
+			}
+			if len(got) != len(want) || int64(len(got[0])) != info.TotalSamples {
+				t.Fatalf("%d kanal x %d namuna, kutilgan %d x %d", len(got), len(got[0]), len(want), info.TotalSamples)
+			}
+			for c := range want {
+				for i := range want[c] {
+					if got[c][i] != want[c][i] {
+						t.Fatalf("kanal %d namuna %d: %v, kutilgan %v", c, i, got[c][i], want[c][i])
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestDecodeLibFLACFixtures - testdata/gen_libflac_fixtures.py da reference libFLAC (flac -8) kodlagan fayllar
+func TestDecodeLibFLACFixtures(t *testing.T) {
+	data, err := os.ReadFile(filepath.Join("testdata", "libflac.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skip("testdata/libflac.json yo‘q: flac/metaflac o‘rnatilgan muhitda testdata/gen_libflac_fixtures.py ni ishga tushiring")
+	}
+	if err != nil {
+		t.Fatalf("fixture o‘qishda xatolik: %v", err)
+	}
+	var fixtures struct {
+		FLACVersion string `json:"flac_version"`
+		Files       []struct {
+			Name       string `json:"name"`
+			SampleRate int    `json:"sample_rate"`
+			Bits       int    `json:"bits"`
+			Channels   int    `json:"channels"`
+			MD5        string `json:"md5"`
+		} `json:"files"`
+	}
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		t.Fatalf("fixture dekodlashda xatolik: %v", err)
+	}
+	if fixtures.FLACVersion == "" {
+		t.Fatal("fixture libFLAC bilan yaratilmagan (flac_version yo‘q)")
+	}
+
+	for _, fx := range fixtures.Files {
+		t.Run(fx.Name, func(t *testing.T) {
+			got, info, err := DecodeFLACWithOptions(bytes.NewReader(readFixture(t, fx.Name+".flac")), FLACOptions{VerifyMD5: true})
+			if err != nil {
+				t.Fatalf("DecodeFLAC xatolik: %v", err)
+			}
+			if info.SampleRate != fx.SampleRate || info.Channels != fx.Channels || info.BitsPerSample != fx.Bits {
+				t.Errorf("kutilmagan STREAMINFO: %+v", info)
+			}
+			if md5 := hex.EncodeToString(info.MD5[:]); md5 != fx.MD5 {
+				t.Errorf("STREAMINFO MD5 %s, kutilgan %s", md5, fx.MD5)
+			}
+			want, _, err := DecodeWAV(bytes.NewReader(readFixture(t, fx.Name+".wav")))
+			if err != nil {
+				t.Fatalf("DecodeWAV xatolik: %v", err)
+			}
+			if len(got) != len(want) || len(got[0]) != len(want[0]) {
+				t.Fatalf("%d kanal x %d namuna, kutilgan %d x %d", len(got), len(got[0]), len(want), len(want[0]))
+			}
+			for c := range want {
+				for i := range want[c] {
+					if got[c][i] != want[c][i] {
+						t.Fatalf("kanal %d namuna %d: %v, kutilgan %v", c, i, got[c][i], want[c][i])
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestDecodeFLACErrors(t *testing.T) {
+	data := readFixture(t, "mono16.flac")
+
+	// "fLaC" + metama’lumot sarlavhasi + 18 bayt STREAMINFO dan keyin MD5 boshlanadi
+	badMD5 := bytes.Clone(data)
+	badMD5[4+4+18] ^= 0xFF
+	if _, _, err := DecodeFLAC(bytes.NewReader(badMD5)); err != nil {
+		t.Errorf("MD5 tekshiruvisiz xatolik kutilmagan edi: %v", err)
+	}
+	if _, _, err := DecodeFLACWithOptions(bytes.NewReader(badMD5), FLACOptions{VerifyMD5: true}); !errors.Is(err, ErrFLACMD5Mismatch) {
+		t.Errorf("ErrFLACMD5Mismatch kutilgan edi, olindi %v", err)
+	}
+
+	corrupt := bytes.Clone(data)
+	corrupt[len(corrupt)/2] ^= 0x10
+	truncated := data[:len(data)-100]
+	for name, input := range map[string][]byte{"crc": corrupt, "truncated": truncated, "wav": readFixture(t, "mono16.wav")} {
+		if _, _, err := DecodeFLAC(bytes.NewReader(input)); !errors.Is(err, ErrInvalidFLAC) {
+			t.Errorf("%s: ErrInvalidFLAC kutilgan edi, olindi %v", name, err)
+		}
+	}
+
+	// Boshida ID3v2 tegi bo‘lgan fayl (footer bayrog‘isiz, 5 bayt teg ma’lumoti)
+	tagged := append([]byte("ID3\x04\x00\x00\x00\x00\x00\x05TAGXX"), data...)
+	if _, _, err := DecodeFLAC(bytes.NewReader(tagged)); err != nil {
+		t.Errorf("ID3 tegli fayl: %v", err)
+	}
+}
+
+func TestLoadAudioFLAC(t *testing.T) {
+	flacAudio, rate, err := LoadAudioWithOptions(filepath.Join("testdata", "stereo16.flac"), LoadOptions{VerifyMD5: true})
+	if err != nil {
+		t.Fatalf("LoadAudio xatolik: %v", err)
+	}
+	wavAudio, _, err := LoadAudio(filepath.Join("testdata", "stereo16.wav"))
+	if err != nil {
+		t.Fatalf("LoadAudio xatolik: %v", err)
+	}
+	if rate != 44100 || len(flacAudio) != len(wavAudio) {
+		t.Fatalf("%d Hz, %d namuna; kutilgan 44100 Hz, %d namuna", rate, len(flacAudio), len(wavAudio))
+	}
+	for i := range wavAudio {
+		if flacAudio[i] != wavAudio[i] {
+			t.Fatalf("namuna %d: %v, kutilgan %v", i, flacAudio[i], wavAudio[i])
+		}
+	}
+
+	// Protsessor orqali o‘qilganda SampleRate ga qayta namunalanadi
+	processor := newTestProcessor(t, DefaultConfig())
+	audio, err := processor.LoadAudio(filepath.Join("testdata", "stereo16.flac"))
+	if err != nil {
+		t.Fatalf("Processor.LoadAudio xatolik: %v", err)
+	}
+	if want := (len(wavAudio)*16000 + 44099) / 44100; len(audio) != want {
+		t.Errorf("qayta namunalangan uzunlik %d, kutilgan %d", len(audio), want)
+	}
+}
@@ -0,0 +1,21 @@
+//go:build !cuda
+
+package mfcc
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewProcessorGPUNotCompiled(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.UseGPU = true
+	processor, err := NewProcessor(cfg)
+	if err == nil {
+		processor.Close()
+		t.Fatal("cuda tegisiz UseGPU xatolik qaytarishi kerak edi")
+	}
+	if !errors.Is(err, ErrGPUNotCompiled) {
+		t.Fatalf("kutilgan ErrGPUNotCompiled, olindi: %v", err)
+	}
+}
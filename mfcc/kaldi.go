@@ -0,0 +1,120 @@
+package mfcc
+
+import (
+	"errors"
+	"fmt"
+)
+
+// KaldiOptions Kaldi `compute-mfcc-feats` buyrug‘i parametrlarini ifodalaydi.
+// Maydonlar Kaldi opsiyalari bilan bir xil nomlangan va bir xil standart qiymatlarga ega
+// (DefaultKaldiOptions). Config metodi ularni oddiy Config ga o‘tkazadi.
+//
+// Kaldi WAV namunalarini int16 diapazonida o‘qiydi, shuning uchun Config [-1, 1] oralig‘idagi
+// audio (masalan, LoadAudio natijasi) uchun SampleScale = 32768 ni o‘rnatadi.
+type KaldiOptions struct {
+	SampleFrequency   int        // --sample-frequency (Hz)
+	FrameShiftMs      float32    // --frame-shift (ms)
+	FrameLengthMs     float32    // --frame-length (ms)
+	Dither            float32    // --dither; natija deterministik bo‘lishi uchun 0 qiling
+	PreemphCoeff      float32    // --preemphasis-coefficient
+	RemoveDCOffset    bool       // --remove-dc-offset
+	WindowType        WindowType // --window-type (Povey, Hamming, Hanning, Rect, Blackman)
+	RoundToPowerOfTwo bool       // --round-to-power-of-two: FFT uzunligini 2 ning darajasiga yaxlitlash
+	SnipEdges         bool       // --snip-edges: false bo‘lsa chetlar Kaldi usulida to‘ldiriladi
+	NumMelBins        int        // --num-mel-bins
+	LowFreq           float32    // --low-freq (Hz)
+	HighFreq          float32    // --high-freq (Hz); 0 yoki manfiy bo‘lsa Nyquist + HighFreq
+	NumCeps           int        // --num-ceps
+	UseEnergy         bool       // --use-energy: C0 o‘rniga log energiya
+	EnergyFloor       float32    // --energy-floor
+	RawEnergy         bool       // --raw-energy: energiyani pre-emphasis va oynadan oldin hisoblash
+	CepstralLifter    int        // --cepstral-lifter (0 - o‘chirilgan)
+}
+
+// DefaultKaldiOptions Kaldi `compute-mfcc-feats` standart parametrlarini qaytaradi.
+func DefaultKaldiOptions() KaldiOptions {
+	return KaldiOptions{
+		SampleFrequency:   16000,
+		FrameShiftMs:      10,
+		FrameLengthMs:     25,
+		Dither:            1,
+		PreemphCoeff:      0.97,
+		RemoveDCOffset:    true,
+		WindowType:        Povey,
+		RoundToPowerOfTwo: true,
+		SnipEdges:         true,
+		NumMelBins:        23,
+		LowFreq:           20,
+		HighFreq:          0,
+		NumCeps:           13,
+		UseEnergy:         true,
+		EnergyFloor:       0,
+		RawEnergy:         true,
+		CepstralLifter:    22,
+	}
+}
+
+// Config Kaldi parametrlarini Config ga o‘tkazadi. Ramka uzunliklari Kaldi kabi
+// namunalarga butun qismigacha yaxlitlanadi; Parallel va MaxConcurrency DefaultConfig dan olinadi.
+func (o KaldiOptions) Config() (Config, error) {
+	if o.SampleFrequency <= 0 {
+		return Config{}, errors.New("sample frequency must be positive")
+	}
+	frameLength := int(float64(o.SampleFrequency) * 0.001 * float64(o.FrameLengthMs))
+	frameShift := int(float64(o.SampleFrequency) * 0.001 * float64(o.FrameShiftMs))
+	if frameLength <= 0 || frameShift <= 0 {
+		return Config{}, errors.New("frame length and frame shift must be at least one sample")
+	}
+	if o.NumCeps > o.NumMelBins {
+		return Config{}, fmt.Errorf("num ceps (%d) must not exceed num mel bins (%d)", o.NumCeps, o.NumMelBins)
+	}
+
+	nyquist := float32(o.SampleFrequency) / 2
+	highFreq := o.HighFreq
+	if highFreq <= 0 {
+		highFreq += nyquist
+	}
+	if o.LowFreq < 0 || highFreq <= o.LowFreq || highFreq > nyquist {
+		return Config{}, fmt.Errorf("invalid mel frequency range [%g, %g] for sample frequency %d", o.LowFreq, highFreq, o.SampleFrequency)
+	}
+
+	fftSize := frameLength
+	if o.RoundToPowerOfTwo {
+		fftSize = 1
+		for fftSize < frameLength {
+			fftSize <<= 1
+		}
+	}
+	padding := PaddingNone
+	if !o.SnipEdges {
+		padding = PaddingKaldi
+	}
+
+	cfg := DefaultConfig()
+	cfg.SampleRate = o.SampleFrequency
+	cfg.FrameLength = frameLength
+	cfg.HopLength = frameShift
+	cfg.FFTSize = fftSize
+	cfg.NumCoefficients = o.NumCeps
+	cfg.NumFilters = o.NumMelBins
+	cfg.WindowType = o.WindowType
+	cfg.PreEmphasis = o.PreemphCoeff
+	cfg.FramePreEmphasis = true
+	cfg.LowFreq = o.LowFreq
+	cfg.HighFreq = highFreq
+	cfg.MelScale = MelScaleKaldi
+	cfg.MelNorm = MelNormNone
+	cfg.Padding = padding
+	cfg.LogMode = LogKaldi
+	cfg.CepLifter = o.CepstralLifter
+	cfg.SampleScale = 32768
+	cfg.Dither = o.Dither
+	cfg.RemoveDCOffset = o.RemoveDCOffset
+	cfg.UseEnergy = o.UseEnergy
+	cfg.RawEnergy = o.RawEnergy
+	cfg.EnergyFloor = o.EnergyFloor
+	if err := cfg.Validate(); err != nil {
+		return Config{}, err
+	}
+	return cfg, nil
+}
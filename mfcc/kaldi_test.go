@@ -0,0 +1,132 @@
+package mfcc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"testing"
+)
+
+// kaldiFixture - testdata/gen_kaldi_fixtures.py yaratgan golden `compute-mfcc-feats` natijalari
+type kaldiFixture struct {
+	SampleFrequency   int         `json:"sample_frequency"`
+	FrameShift        float32     `json:"frame_shift"`
+	FrameLength       float32     `json:"frame_length"`
+	Dither            float32     `json:"dither"`
+	PreemphCoeff      float32     `json:"preemphasis_coefficient"`
+	RemoveDCOffset    bool        `json:"remove_dc_offset"`
+	WindowType        string      `json:"window_type"`
+	RoundToPowerOfTwo bool        `json:"round_to_power_of_two"`
+	SnipEdges         bool        `json:"snip_edges"`
+	NumMelBins        int         `json:"num_mel_bins"`
+	LowFreq           float32     `json:"low_freq"`
+	HighFreq          float32     `json:"high_freq"`
+	NumCeps           int         `json:"num_ceps"`
+	UseEnergy         bool        `json:"use_energy"`
+	EnergyFloor       float32     `json:"energy_floor"`
+	RawEnergy         bool        `json:"raw_energy"`
+	CepstralLifter    float64     `json:"cepstral_lifter"`
+	NumSamples        int         `json:"num_samples"`
+	Feats             [][]float64 `json:"feats"`
+}
+
+func (f kaldiFixture) options() KaldiOptions {
+	return KaldiOptions{
+		SampleFrequency:   f.SampleFrequency,
+		FrameShiftMs:      f.FrameShift,
+		FrameLengthMs:     f.FrameLength,
+		Dither:            f.Dither,
+		PreemphCoeff:      f.PreemphCoeff,
+		RemoveDCOffset:    f.RemoveDCOffset,
+		WindowType:        WindowType(f.WindowType),
+		RoundToPowerOfTwo: f.RoundToPowerOfTwo,
+		SnipEdges:         f.SnipEdges,
+		NumMelBins:        f.NumMelBins,
+		LowFreq:           f.LowFreq,
+		HighFreq:          f.HighFreq,
+		NumCeps:           f.NumCeps,
+		UseEnergy:         f.UseEnergy,
+		EnergyFloor:       f.EnergyFloor,
+		RawEnergy:         f.RawEnergy,
+		CepstralLifter:    int(f.CepstralLifter),
+	}
+}
+
+// kaldiSignal - Generator skriptidagi int16 qiymatli test signalining [-1, 1] ga normallangan nusxasi
+func kaldiSignal(sr, n int) []float32 {
+	out := make([]float32, n)
+	state := int64(12345)
+	for i := range out {
+		t := float64(i) / float64(sr)
+		state = (state*1103515245 + 12345) % 2147483648
+		noise := float64(state%401 - 200)
+		sweep := 300.0 + 2000.0*float64(i)/float64(n)
+		v := 6000*math.Sin(2*math.Pi*220*t) + 3000*math.Sin(2*math.Pi*1800*t) +
+			800*math.Sin(2*math.Pi*sweep*t) + noise
+		out[i] = float32(math.Floor(v+0.5) / 32768)
+	}
+	return out
+}
+
+func TestKaldiGolden(t *testing.T) {
+	data, err := os.ReadFile("testdata/kaldi_mfcc.json")
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skip("testdata/kaldi_mfcc.json yo‘q: Kaldi o‘rnatilgan muhitda testdata/gen_kaldi_fixtures.py ni ishga tushiring")
+	}
+	if err != nil {
+		t.Fatalf("fixture o‘qishda xatolik: %v", err)
+	}
+	var fixtures struct {
+		KaldiRevision string         `json:"kaldi_revision"`
+		Cases         []kaldiFixture `json:"cases"`
+	}
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		t.Fatalf("fixture dekodlashda xatolik: %v", err)
+	}
+	if fixtures.KaldiRevision == "" {
+		t.Fatal("fixture compute-mfcc-feats bilan yaratilmagan (kaldi_revision yo‘q)")
+	}
+
+	for i, fixture := range fixtures.Cases {
+		t.Run(fmt.Sprintf("%d_%s_snip=%v", i, fixture.WindowType, fixture.SnipEdges), func(t *testing.T) {
+			cfg, err := fixture.options().Config()
+			if err != nil {
+				t.Fatalf("KaldiOptions.Config xatolik: %v", err)
+			}
+			feats, err := newTestProcessor(t, cfg).Process(kaldiSignal(fixture.SampleFrequency, fixture.NumSamples))
+			if err != nil {
+				t.Fatalf("Process xatolik: %v", err)
+			}
+			// float32 hisoblash farqi uchun nisbiy chegara
+			if len(feats) != len(fixture.Feats) {
+				t.Fatalf("ramkalar soni %d, kutilgan %d", len(feats), len(fixture.Feats))
+			}
+			for j, want := range fixture.Feats {
+				for k, expected := range want {
+					if math.Abs(float64(feats[j][k])-expected) > 2e-3*math.Max(1, math.Abs(expected)) {
+						t.Fatalf("ramka %d, koeffitsient %d: %v, kutilgan %v", j, k, feats[j][k], expected)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestKaldiOptionsConfig(t *testing.T) {
+	cfg, err := DefaultKaldiOptions().Config()
+	if err != nil {
+		t.Fatalf("KaldiOptions.Config xatolik: %v", err)
+	}
+	if cfg.FrameLength != 400 || cfg.HopLength != 160 || cfg.FFTSize != 512 || cfg.HighFreq != 8000 {
+		t.Errorf("kutilmagan konfiguratsiya: %+v", cfg)
+	}
+
+	opts := DefaultKaldiOptions()
+	opts.NumCeps = 30
+	if _, err := opts.Config(); err == nil {
+		t.Error("NumCeps > NumMelBins uchun xatolik kutilgan edi")
+	}
+}
@@ -0,0 +1,107 @@
+package mfcc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"testing"
+)
+
+// librosaFixture - testdata/gen_librosa_fixtures.py librosa orqali yaratgan golden MFCC va log-Mel qiymatlari
+type librosaFixture struct {
+	SampleRate int         `json:"sr"`
+	NFFT       int         `json:"n_fft"`
+	HopLength  int         `json:"hop_length"`
+	NumMels    int         `json:"n_mels"`
+	NumMFCC    int         `json:"n_mfcc"`
+	NumSamples int         `json:"num_samples"`
+	MFCC       [][]float64 `json:"mfcc"`
+	LogMel     [][]float64 `json:"log_mel"`
+}
+
+// librosaSignal - Generator skriptidagi test signalining aynan nusxasi
+func librosaSignal(sr, n int) []float32 {
+	out := make([]float32, n)
+	for i := range out {
+		t := float64(i) / float64(sr)
+		sweep := 100.0 + 1500.0*float64(i)/float64(n)
+		out[i] = float32(0.5*math.Sin(2*math.Pi*440*t) +
+			0.25*math.Sin(2*math.Pi*1234.5*t) +
+			0.1*math.Sin(2*math.Pi*sweep*t))
+	}
+	return out
+}
+
+func compareMatrix(t *testing.T, name string, got [][]float32, want [][]float64, tol float64) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%s: ramkalar soni %d, kutilgan %d", name, len(got), len(want))
+	}
+	for i := range want {
+		if len(got[i]) < len(want[i]) {
+			t.Fatalf("%s: ramka %d uzunligi %d, kutilgan %d", name, i, len(got[i]), len(want[i]))
+		}
+		for j, expected := range want[i] {
+			if math.Abs(float64(got[i][j])-expected) > tol {
+				t.Fatalf("%s: ramka %d, qiymat %d: %v, kutilgan %v", name, i, j, got[i][j], expected)
+			}
+		}
+	}
+}
+
+func TestCompatLibrosaGolden(t *testing.T) {
+	data, err := os.ReadFile("testdata/librosa_mfcc.json")
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skip("testdata/librosa_mfcc.json yo‘q: librosa o‘rnatilgan muhitda testdata/gen_librosa_fixtures.py ni ishga tushiring")
+	}
+	if err != nil {
+		t.Fatalf("fixture o‘qishda xatolik: %v", err)
+	}
+	var fixtures struct {
+		LibrosaVersion string           `json:"librosa_version"`
+		Cases          []librosaFixture `json:"cases"`
+	}
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		t.Fatalf("fixture dekodlashda xatolik: %v", err)
+	}
+	if fixtures.LibrosaVersion == "" {
+		t.Fatal("fixture librosa.feature.mfcc bilan yaratilmagan (librosa_version yo‘q)")
+	}
+
+	for _, fixture := range fixtures.Cases {
+		t.Run(fmt.Sprintf("%d_%d", fixture.SampleRate, fixture.NFFT), func(t *testing.T) {
+			cfg, err := PresetConfig(CompatLibrosa)
+			if err != nil {
+				t.Fatalf("PresetConfig xatolik: %v", err)
+			}
+			cfg.SampleRate = fixture.SampleRate
+			cfg.FrameLength = fixture.NFFT
+			cfg.HopLength = fixture.HopLength
+			cfg.NumFilters = fixture.NumMels
+			cfg.NumCoefficients = fixture.NumMFCC
+			proc := newTestProcessor(t, cfg)
+
+			audio := librosaSignal(fixture.SampleRate, fixture.NumSamples)
+			logMel, err := proc.LogMelSpectrogram(audio)
+			if err != nil {
+				t.Fatalf("LogMelSpectrogram xatolik: %v", err)
+			}
+			compareMatrix(t, "log_mel", logMel, fixture.LogMel, 1e-2)
+
+			mfccs, err := proc.Process(audio)
+			if err != nil {
+				t.Fatalf("Process xatolik: %v", err)
+			}
+			compareMatrix(t, "mfcc", mfccs, fixture.MFCC, 5e-2)
+		})
+	}
+}
+
+func TestPresetConfigUnknown(t *testing.T) {
+	if _, err := PresetConfig("essentia"); err == nil {
+		t.Error("noma’lum preset uchun xatolik kutilgan edi")
+	}
+}
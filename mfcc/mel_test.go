@@ -0,0 +1,90 @@
+package mfcc
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"testing"
+)
+
+// melFixture - testdata/gen_mel_fixtures.py librosa.filters.mel orqali yaratgan golden Mel filtrlar banki
+type melFixture struct {
+	SampleRate int         `json:"sr"`
+	NFFT       int         `json:"n_fft"`
+	NumMels    int         `json:"n_mels"`
+	FMin       float32     `json:"fmin"`
+	FMax       *float32    `json:"fmax"`
+	HTK        bool        `json:"htk"`
+	Norm       interface{} `json:"norm"`
+	Filters    []struct {
+		Offset  int       `json:"offset"`
+		Weights []float64 `json:"weights"`
+	} `json:"filters"`
+}
+
+func (f melFixture) config() Config {
+	cfg := DefaultConfig()
+	cfg.SampleRate = f.SampleRate
+	cfg.FrameLength = f.NFFT
+	cfg.HopLength = f.NFFT / 4
+	cfg.NumFilters = f.NumMels
+	cfg.LowFreq = f.FMin
+	if f.FMax != nil {
+		cfg.HighFreq = *f.FMax
+	}
+	cfg.MelScale = MelScaleSlaney
+	if f.HTK {
+		cfg.MelScale = MelScaleHTK
+	}
+	switch norm := f.Norm.(type) {
+	case string:
+		cfg.MelNorm = MelNorm(norm)
+	case float64:
+		cfg.MelNorm = MelNorm(fmt.Sprintf("l%d", int(norm)))
+	}
+	return cfg
+}
+
+func TestMelFilterBankGolden(t *testing.T) {
+	data, err := os.ReadFile("testdata/mel_filters.json")
+	if errors.Is(err, fs.ErrNotExist) {
+		t.Skip("testdata/mel_filters.json yo‘q: librosa o‘rnatilgan muhitda testdata/gen_mel_fixtures.py ni ishga tushiring")
+	}
+	if err != nil {
+		t.Fatalf("fixture o‘qishda xatolik: %v", err)
+	}
+	var fixtures struct {
+		LibrosaVersion string       `json:"librosa_version"`
+		Cases          []melFixture `json:"cases"`
+	}
+	if err := json.Unmarshal(data, &fixtures); err != nil {
+		t.Fatalf("fixture dekodlashda xatolik: %v", err)
+	}
+	if fixtures.LibrosaVersion == "" {
+		t.Fatal("fixture librosa.filters.mel bilan yaratilmagan (librosa_version yo‘q)")
+	}
+
+	for _, fixture := range fixtures.Cases {
+		cfg := fixture.config()
+		t.Run(fmt.Sprintf("%d_%d_%s_%s", cfg.SampleRate, cfg.FrameLength, cfg.MelScale, cfg.MelNorm), func(t *testing.T) {
+			filters := newTestProcessor(t, cfg).MelFilterBank()
+			if len(filters) != len(fixture.Filters) {
+				t.Fatalf("filtrlar soni %d, kutilgan %d", len(filters), len(fixture.Filters))
+			}
+			for i, want := range fixture.Filters {
+				for j, got := range filters[i] {
+					expected := 0.0
+					if k := j - want.Offset; k >= 0 && k < len(want.Weights) {
+						expected = want.Weights[k]
+					}
+					if math.Abs(float64(got)-expected) > 1e-6*math.Max(math.Abs(expected), 1e-3) {
+						t.Fatalf("filtr %d bin %d: %v, kutilgan %v", i, j, got, expected)
+					}
+				}
+			}
+		})
+	}
+}
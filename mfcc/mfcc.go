@@ -1,10 +1,9 @@
 package mfcc
 
 import (
+	"context"
 	"errors"
 	"fmt"
-	"math"
-	"sync"
 
 	"github.com/BaxtiyorUrolov/go-mfcc/internal"
 )
@@ -17,48 +16,115 @@ const (
 	Hanning  WindowType = "hanning"     // Hanning oynasi turi
 	Blackman WindowType = "blackman"    // Blackman oynasi turi
 	Rect     WindowType = "rectangular" // To‘rtburchak oynasi turi
+	Povey    WindowType = "povey"       // Povey oynasi (Kaldi standarti): Hanning^0.85
+)
+
+// ErrGPUNotCompiled - UseGPU yoqilgan, lekin kutubxona `cuda` build tegisiz yig‘ilgan
+var ErrGPUNotCompiled = internal.ErrGPUNotCompiled
+
+// ErrAudioTooShort - Padding o‘chirilgan (yoki Kaldi rejimi) va audio bitta ramkadan ham qisqa
+var ErrAudioTooShort = internal.ErrAudioTooShort
+
+// MelScale - Hz va Mel shkalasi orasidagi o‘tkazish formulasi
+type MelScale string
+
+const (
+	MelScaleHTK    MelScale = "htk"    // HTK formulasi: 2595·log10(1 + f/700)
+	MelScaleSlaney MelScale = "slaney" // Slaney (librosa standarti): 1 kHz gacha chiziqli, undan keyin logarifmik
+	MelScaleKaldi  MelScale = "kaldi"  // Kaldi: 1127·ln(1 + f/700), uchburchaklar Mel sohasida chiziqli
+)
+
+// MelNorm - Mel filtrlarini normallashtirish usuli
+type MelNorm string
+
+const (
+	MelNormNone   MelNorm = "none"   // Normalizatsiya yo‘q (uchburchak cho‘qqisi 1)
+	MelNormSlaney MelNorm = "slaney" // Har bir filtr yuzasini tenglashtirish (librosa `norm='slaney'`)
+	MelNormL1     MelNorm = "l1"     // Har bir filtr L1 normasi 1 ga teng
+	MelNormL2     MelNorm = "l2"     // Har bir filtr L2 normasi 1 ga teng
+)
+
+// PaddingMode - Signal chegaralarini ramkalashdan oldin to‘ldirish usuli
+type PaddingMode string
+
+const (
+	PaddingNone   PaddingMode = "none"   // To‘ldirish yo‘q (snip), faqat to‘liq ramkalar olinadi
+	PaddingCenter PaddingMode = "center" // Signal ikki tomondan FrameLength/2 ga to‘ldiriladi (librosa `center=True`)
+	PaddingTail   PaddingMode = "tail"   // Oxirgi to‘liq bo‘lmagan ramka nollar bilan to‘ldiriladi, dum namunalari tashlanmaydi
+	PaddingKaldi  PaddingMode = "kaldi"  // Kaldi `snip_edges=false`: ramka markazlari (t+0.5)·HopLength da, chetlar symmetric aks
+)
+
+// PadMode - To‘ldirilgan namunalar qiymatini aniqlash usuli
+type PadMode string
+
+const (
+	PadReflect   PadMode = "reflect"   // Chegara namunasisiz ko‘zgu aksi (numpy `reflect`)
+	PadConstant  PadMode = "constant"  // Nollar bilan to‘ldirish
+	PadSymmetric PadMode = "symmetric" // Chegara namunasini ham o‘z ichiga olgan ko‘zgu aksi (numpy `symmetric`, Kaldi)
+	PadEdge      PadMode = "edge"      // Chegara namunasini takrorlash (numpy `edge`)
+)
+
+// LogMode - Mel energiyalarini logarifmik shkalaga o‘tkazish usuli
+type LogMode string
+
+const (
+	LogNatural LogMode = "natural" // ln(x + 1e-6)
+	LogDB      LogMode = "db"      // 10·log10(max(x, 1e-10)), librosa `power_to_db(ref=1.0)`
+	LogKaldi   LogMode = "kaldi"   // ln(max(x, FLT_EPSILON)), Kaldi `compute-mfcc-feats`
 )
 
 // Config - MFCC hisoblash konfiguratsiyasi uchun tuzilma
 type Config struct {
-	SampleRate      int        `json:"sample_rate"`      // Audio namunalar tezligi (Hz)
-	FrameLength     int        `json:"frame_length"`     // Har bir ramkaning uzunligi
-	HopLength       int        `json:"hop_length"`       // Ramkalar orasidagi qadam uzunligi
-	NumCoefficients int        `json:"num_coefficients"` // MFCC koeffitsientlari soni
-	NumFilters      int        `json:"num_filters"`      // Mel filtrlar banki soni
-	WindowType      WindowType `json:"window_type"`      // Ishlatiladigan oyna turi
-	PreEmphasis     float32    `json:"pre_emphasis"`     // Pre-emphasis koeffitsienti
-	UseGPU          bool       `json:"use_gpu"`          // GPU ishlatishni yoqish/o‘chirish
-	Parallel        bool       `json:"parallel"`         // Parallel hisoblashni yoqish/o‘chirish
-	MaxConcurrency  int        `json:"max_concurrency"`  // Maksimal parallel goroutinlar soni
-	LowFreq         float32    `json:"low_freq"`         // Mel filtrlar uchun past chastota chegarasi (Hz)
-	HighFreq        float32    `json:"high_freq"`        // Mel filtrlar uchun yuqori chastota chegarasi (Hz)
-}
-
-// Validate - Konfiguratsiyani tekshirish funksiyasi
+	SampleRate       int             `json:"sample_rate"`        // Audio namunalar tezligi (Hz)
+	FrameLength      int             `json:"frame_length"`       // Har bir ramkaning uzunligi
+	HopLength        int             `json:"hop_length"`         // Ramkalar orasidagi qadam uzunligi
+	NumCoefficients  int             `json:"num_coefficients"`   // MFCC koeffitsientlari soni
+	NumFilters       int             `json:"num_filters"`        // Mel filtrlar banki soni
+	WindowType       WindowType      `json:"window_type"`        // Ishlatiladigan oyna turi
+	PreEmphasis      float32         `json:"pre_emphasis"`       // Pre-emphasis koeffitsienti
+	UseGPU           bool            `json:"use_gpu"`            // GPU ishlatishni yoqish/o‘chirish
+	Parallel         bool            `json:"parallel"`           // Parallel hisoblashni yoqish/o‘chirish
+	MaxConcurrency   int             `json:"max_concurrency"`    // Maksimal parallel goroutinlar soni
+	LowFreq          float32         `json:"low_freq"`           // Mel filtrlar uchun past chastota chegarasi (Hz)
+	HighFreq         float32         `json:"high_freq"`          // Mel filtrlar uchun yuqori chastota chegarasi (Hz)
+	Backend          string          `json:"backend"`            // Hisoblash backend’i nomi (bo‘sh bo‘lsa UseGPU ga qarab tanlanadi)
+	BackendFallback  bool            `json:"backend_fallback"`   // Backend xatolik bersa CPU’ga o‘tish
+	DeltaOrder       int             `json:"delta_order"`        // Delta tartibi: 0 - yo‘q, 1 - Δ, 2 - Δ va ΔΔ
+	DeltaWindow      int             `json:"delta_window"`       // Delta regressiya oynasi yarim kengligi (N)
+	CMVN             CMVNMode        `json:"cmvn"`               // CMVN turi (bo‘sh bo‘lsa normalizatsiya yo‘q)
+	CMVNNormVars     bool            `json:"cmvn_norm_vars"`     // Dispersiyani ham normallashtirish
+	CMVNWindow       int             `json:"cmvn_window"`        // Sirpanuvchi CMVN oynasi uzunligi (ramkalar)
+	CMVNCenter       bool            `json:"cmvn_center"`        // Sirpanuvchi oynani joriy ramka atrofida markazlash
+	CMVNStatsFile    string          `json:"cmvn_stats_file"`    // Global CMVN statistikasi fayli
+	CepLifter        int             `json:"cep_lifter"`         // Kepstral lifter parametri L (0 - o‘chirilgan, HTK/Kaldi standarti 22)
+	MelScale         MelScale        `json:"mel_scale"`          // Mel shkalasi formulasi: "htk" (standart), "slaney" yoki "kaldi"
+	MelNorm          MelNorm         `json:"mel_norm"`           // Mel filtrlarini normallashtirish: "none", "slaney", "l1" yoki "l2"
+	PeriodicWindow   bool            `json:"periodic_window"`    // Davriy oyna (N davr), librosa/scipy `fftbins=True`
+	Padding          PaddingMode     `json:"padding"`            // Signal chegaralarini to‘ldirish: "none" (standart), "tail", "center" yoki "kaldi"
+	PadMode          PadMode         `json:"pad_mode"`           // To‘ldirish qiymatlari: "reflect" (standart), "constant", "symmetric" yoki "edge"
+	LogMode          LogMode         `json:"log_mode"`           // Logarifm turi: "natural" (standart), "db" yoki "kaldi"
+	TopDB            float32         `json:"top_db"`             // dB rejimida maksimumdan pastdagi kesish chegarasi (0 - o‘chirilgan)
+	FFTSize          int             `json:"fft_size"`           // FFT uzunligi (0 bo‘lsa FrameLength); ramka oxiri nollar bilan to‘ldiriladi
+	SampleScale      float32         `json:"sample_scale"`       // Kirish namunalari ko‘paytuvchisi (0 - o‘zgarishsiz, Kaldi int16 shkalasi uchun 32768)
+	Dither           float32         `json:"dither"`             // Har bir ramkaga qo‘shiladigan Gauss shovqini amplitudasi (0 - o‘chirilgan)
+	RemoveDCOffset   bool            `json:"remove_dc_offset"`   // Har bir ramkadan o‘rtacha qiymatni ayirish
+	FramePreEmphasis bool            `json:"frame_pre_emphasis"` // Pre-emphasis ni butun signalga emas, har bir ramkaga alohida qo‘llash (Kaldi)
+	UseEnergy        bool            `json:"use_energy"`         // C0 o‘rniga ramkaning log energiyasini yozish
+	RawEnergy        bool            `json:"raw_energy"`         // Energiyani pre-emphasis va oynadan oldin hisoblash
+	EnergyFloor      float32         `json:"energy_floor"`       // Log energiya uchun pastki chegara (0 - o‘chirilgan)
+	ResampleQuality  ResampleQuality `json:"resample_quality"`   // Boshqa tezlikdagi audioni SampleRate ga o‘tkazish sifati (bo‘sh bo‘lsa "high")
+	PitchMethod      PitchMethod     `json:"pitch_method"`       // Pitch algoritmi: "yin" (standart) yoki "pyin"
+	PitchFmin        float32         `json:"pitch_fmin"`         // Minimal f0 (Hz, 0 bo‘lsa max(50, 2·SampleRate/FrameLength)); davri FrameLength/2 dan oshmasligi kerak
+	PitchFmax        float32         `json:"pitch_fmax"`         // Maksimal f0 (Hz, 0 bo‘lsa min(400, SampleRate/4))
+	PitchThreshold   float32         `json:"pitch_threshold"`    // YIN absolyut chegarasi (0 bo‘lsa 0.1)
+	Features         FeatureSet      `json:"features"`           // ProcessFeatures hisoblaydigan xususiyatlar (0 bo‘lsa barchasi)
+}
+
+// Validate - Konfiguratsiyani tekshirish funksiyasi. Qoidalar bitta joyda saqlanishi uchun tekshiruv
+// internal.Config.Validate ga topshiriladi.
 func (c *Config) Validate() error {
-	if c.SampleRate <= 0 {
-		return errors.New("sample rate must be positive")
-	}
-	if c.FrameLength <= 0 {
-		return errors.New("frame length must be positive")
-	}
-	if c.HopLength <= 0 {
-		return errors.New("hop length must be positive")
-	}
-	if c.NumCoefficients <= 0 {
-		return errors.New("number of coefficients must be positive")
-	}
-	if c.NumFilters <= 0 {
-		return errors.New("number of filters must be positive")
-	}
-	if c.PreEmphasis < 0 || c.PreEmphasis >= 1 {
-		return errors.New("pre-emphasis coefficient must be in [0, 1) range")
-	}
-	if c.MaxConcurrency < 1 {
-		return errors.New("max concurrency must be at least 1")
-	}
-	return nil
+	ic := c.toInternal()
+	return ic.Validate()
 }
 
 // DefaultConfig - Standart konfiguratsiyani qaytarish
@@ -73,6 +139,9 @@ func DefaultConfig() Config {
 		PreEmphasis:     0.97,
 		Parallel:        true,
 		MaxConcurrency:  4,
+		DeltaWindow:     2,
+		CMVN:            CMVNNone,
+		CMVNWindow:      600,
 	}
 }
 
@@ -84,6 +153,55 @@ func (c Config) String() string {
 	)
 }
 
+// toInternal - Ommaviy konfiguratsiyani ichki paket konfiguratsiyasiga o‘tkazish
+func (c Config) toInternal() internal.Config {
+	return internal.Config{
+		SampleRate:       c.SampleRate,
+		FrameLength:      c.FrameLength,
+		HopLength:        c.HopLength,
+		NumCoefficients:  c.NumCoefficients,
+		NumFilters:       c.NumFilters,
+		WindowType:       internal.WindowType(c.WindowType),
+		PreEmphasis:      c.PreEmphasis,
+		UseGPU:           c.UseGPU,
+		Parallel:         c.Parallel,
+		MaxConcurrency:   c.MaxConcurrency,
+		LowFreq:          c.LowFreq,
+		HighFreq:         c.HighFreq,
+		Backend:          c.Backend,
+		BackendFallback:  c.BackendFallback,
+		DeltaOrder:       c.DeltaOrder,
+		DeltaWindow:      c.DeltaWindow,
+		CMVN:             internal.CMVNMode(c.CMVN),
+		CMVNNormVars:     c.CMVNNormVars,
+		CMVNWindow:       c.CMVNWindow,
+		CMVNCenter:       c.CMVNCenter,
+		CMVNStatsFile:    c.CMVNStatsFile,
+		CepLifter:        c.CepLifter,
+		MelScale:         internal.MelScale(c.MelScale),
+		MelNorm:          internal.MelNorm(c.MelNorm),
+		PeriodicWindow:   c.PeriodicWindow,
+		Padding:          internal.PaddingMode(c.Padding),
+		PadMode:          internal.PadMode(c.PadMode),
+		LogMode:          internal.LogMode(c.LogMode),
+		TopDB:            c.TopDB,
+		FFTSize:          c.FFTSize,
+		SampleScale:      c.SampleScale,
+		Dither:           c.Dither,
+		RemoveDCOffset:   c.RemoveDCOffset,
+		FramePreEmphasis: c.FramePreEmphasis,
+		UseEnergy:        c.UseEnergy,
+		RawEnergy:        c.RawEnergy,
+		EnergyFloor:      c.EnergyFloor,
+		ResampleQuality:  internal.ResampleQuality(c.ResampleQuality),
+		PitchMethod:      internal.PitchMethod(c.PitchMethod),
+		PitchFmin:        c.PitchFmin,
+		PitchFmax:        c.PitchFmax,
+		PitchThreshold:   c.PitchThreshold,
+		Features:         internal.FeatureSet(c.Features),
+	}
+}
+
 // Processor audio xususiyatlarini chiqarish uchun ishlatiladigan MFCC protsessorini ifodalaydi.
 type Processor struct {
 	proc *internal.Processor
@@ -94,117 +212,60 @@ func NewProcessor(cfg Config) (*Processor, error) {
 	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
-	internalCfg := internal.Config{
-		SampleRate:      cfg.SampleRate,
-		FrameLength:     cfg.FrameLength,
-		HopLength:       cfg.HopLength,
-		NumCoefficients: cfg.NumCoefficients,
-		NumFilters:      cfg.NumFilters,
-		WindowType:      internal.WindowType(cfg.WindowType),
-		PreEmphasis:     cfg.PreEmphasis,
-		UseGPU:          cfg.UseGPU,
-		Parallel:        cfg.Parallel,
-		MaxConcurrency:  cfg.MaxConcurrency,
-		LowFreq:         cfg.LowFreq,
-		HighFreq:        cfg.HighFreq,
-	}
-	proc, err := internal.NewProcessor(internalCfg)
+	proc, err := internal.NewProcessor(cfg.toInternal())
 	if err != nil {
 		return nil, fmt.Errorf("protsessor yaratishda xatolik: %w", err)
 	}
 	return &Processor{proc: proc}, nil
 }
 
+// MelFilterBank protsessor ishlatayotgan Mel filtrlar banki nusxasini qaytaradi ([NumFilters][FFT uzunligi/2+1]).
+func (p *Processor) MelFilterBank() [][]float32 {
+	return p.proc.FilterBanks()
+}
+
+// FrameTimes birinchi numFrames ta ramka markazining vaqtini (soniya) qaytaradi.
+// Padding hisobga olinadi: PaddingCenter da t-ramka t*HopLength/SampleRate ga to‘g‘ri keladi (librosa `frames_to_time`).
+func (p *Processor) FrameTimes(numFrames int) []float64 {
+	return p.proc.FrameTimes(numFrames)
+}
+
+// BackendName protsessor ishlatayotgan hisoblash backend’ining nomini qaytaradi.
+func (p *Processor) BackendName() string {
+	return p.proc.BackendName()
+}
+
 // Process bitta audio signalidan MFCC xususiyatlarini hisoblaydi.
+// DeltaOrder > 0 bo‘lsa, har bir ramka vektori [MFCC, Δ, ΔΔ] ketma-ketligida qaytariladi.
 func (p *Processor) Process(audio []float32) ([][]float32, error) {
+	return p.ProcessContext(context.Background(), audio)
+}
+
+// ProcessContext Process kabi ishlaydi; ctx bekor qilinsa yoki muddati tugasa hisoblash to‘xtatiladi
+// va ctx.Err() qaytariladi.
+func (p *Processor) ProcessContext(ctx context.Context, audio []float32) ([][]float32, error) {
 	if len(audio) == 0 {
 		return nil, errors.New("bo‘sh audio kirishi")
 	}
 
-	// internal.Processor.Process dan FrameFeatures olish
-	features, err := p.proc.Process(audio)
+	// internal.Processor.ProcessFeatures dan faqat MFCC hisoblangan FrameFeatures olish
+	features, err := p.proc.ProcessFeatures(ctx, audio, internal.FeatureMFCC)
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		return nil, fmt.Errorf("xususiyatlarni hisoblashda xatolik: %w", err)
 	}
 
-	// Faqat MFCC ni qaytarish uchun FrameFeatures dan MFCC ni ajratib olamiz
+	// FrameFeatures dan MFCC (va deltalar) vektorini ajratib olamiz
 	mfccs := make([][]float32, len(features))
 	for i, frame := range features {
-		mfccs[i] = frame.MFCC
+		mfccs[i] = frame.Vector()
 	}
 
 	return mfccs, nil
 }
 
-// normalizeMFCC normalizes MFCC coefficients (zero-mean, unit-variance).
-func normalizeMFCC(mfccs [][][]float32) [][][]float32 {
-	normalized := make([][][]float32, len(mfccs))
-	for i := range mfccs {
-		normalized[i] = make([][]float32, len(mfccs[i]))
-		for j := range mfccs[i] {
-			frame := mfccs[i][j]
-			mean := float32(0)
-			for _, val := range frame {
-				mean += val
-			}
-			mean /= float32(len(frame))
-
-			variance := float32(0)
-			for _, val := range frame {
-				diff := val - mean
-				variance += diff * diff
-			}
-			variance /= float32(len(frame))
-			stdDev := float32(math.Sqrt(float64(variance)))
-
-			normalized[i][j] = make([]float32, len(frame))
-			for k, val := range frame {
-				if stdDev > 0 {
-					normalized[i][j][k] = (val - mean) / stdDev
-				} else {
-					normalized[i][j][k] = 0
-				}
-			}
-		}
-	}
-	return normalized
-}
-
-// ProcessBatch bir nechta audio signallarni parallel ravishda qayta ishlaydi.
-func (p *Processor) ProcessBatch(audios [][]float32) ([][][]float32, error) {
-	if len(audios) == 0 {
-		return nil, errors.New("bo‘sh audio partiyasi")
-	}
-
-	results := make([][][]float32, len(audios))
-	numWorkers := p.proc.Config().MaxConcurrency
-	chunkSize := (len(audios) + numWorkers - 1) / numWorkers
-
-	var wg sync.WaitGroup
-	wg.Add(numWorkers)
-
-	for i := 0; i < numWorkers; i++ {
-		start := i * chunkSize
-		end := start + chunkSize
-		if end > len(audios) {
-			end = len(audios)
-		}
-		go func(start, end int) {
-			defer wg.Done()
-			for j := start; j < end; j++ {
-				mfccs, err := p.Process(audios[j])
-				if err != nil {
-					continue
-				}
-				results[j] = mfccs
-			}
-		}(start, end)
-	}
-
-	wg.Wait()
-	return normalizeMFCC(results), nil
-}
-
 // Close protsessor resurslarini ozod qiladi.
 func (p *Processor) Close() error {
 	return p.proc.Close()
@@ -212,26 +273,67 @@ func (p *Processor) Close() error {
 
 // Streamer real-vaqt audio uchun MFCC hisoblashini boshqaradi.
 type Streamer struct {
-	s *internal.Streamer
+	s   *internal.Streamer
+	err error // Streamer yaratishdagi xatolik; bo‘lsa s nil
 }
 
-// NewStreamer yangi streaming protsessorini yaratadi.
+// NewStreamer yangi streaming protsessorini yaratadi. CMVN va Padding Process bilan bir xil qo‘llanadi.
+// Oqimda ishlatib bo‘lmaydigan sozlamalar (CMVNUtterance va LogDB rejimidagi TopDB) uchun streamer
+// ishlamaydi: Write va Flush e’tiborsiz qoldiriladi, Read nil qaytaradi va Err xatolikni bildiradi.
 func (p *Processor) NewStreamer() *Streamer {
-	return &Streamer{s: p.proc.NewStreamer()}
+	return p.NewStreamerContext(context.Background())
 }
 
-// Write audio namunalarini streamga yozadi.
+// NewStreamerContext ctx ga bog‘langan streamer yaratadi: ctx bekor qilinganda fon goroutine to‘xtaydi,
+// Read nil qaytaradi va Err kontekst xatoligini bildiradi. Close baribir chaqirilishi kerak.
+func (p *Processor) NewStreamerContext(ctx context.Context) *Streamer {
+	s, err := p.proc.NewStreamerContext(ctx)
+	if err != nil {
+		return &Streamer{err: fmt.Errorf("streamer yaratishda xatolik: %w", err)}
+	}
+	return &Streamer{s: s}
+}
+
+// Write audio namunalarini streamga yozadi. Namunalar nusxalanadi va fon goroutine’da qayta ishlanadi;
+// pre-emphasis, ramkalar ustma-ustligi va Padding Write chaqiruvlari orasida saqlanadi.
 func (s *Streamer) Write(data []float32) {
+	if s.err != nil {
+		return
+	}
 	s.s.Write(data)
 }
 
-// Read streamdan MFCC xususiyatlarini oladi.
+// Read streamdan MFCC xususiyatlarini oladi. Ramkalar o‘qilmaguncha kutib turadi va tashlab yuborilmaydi.
 func (s *Streamer) Read() []float32 {
+	if s.err != nil {
+		return nil
+	}
 	// internal.Streamer.Read allaqachon []float32 qaytaradi, to‘g‘ridan-to‘g‘ri qaytaramiz
 	return s.s.Read()
 }
 
-// Close streamerni to‘xtatadi.
+// Flush joriy oqimni yakunlaydi: oxirgi ramkalar (Padding bo‘yicha to‘ldirilgan) va delta buferida qolgan
+// ramkalar chiqariladi, keyingi Write lar yangi oqim sifatida boshlanadi. Oqim oxirida chaqirilishi kerak.
+func (s *Streamer) Flush() {
+	if s.err != nil {
+		return
+	}
+	s.s.Flush()
+}
+
+// Err streamer yaratilmagan bo‘lsa shu xatolikni, konteksti bekor qilingan bo‘lsa ctx.Err() ni, aks holda
+// birinchi hisoblash xatoligini (masalan, Flush paytida juda qisqa oqim uchun ErrAudioTooShort) qaytaradi.
+func (s *Streamer) Err() error {
+	if s.err != nil {
+		return s.err
+	}
+	return s.s.Err()
+}
+
+// Close streamerni to‘xtatadi. Close dan keyingi Write va Flush chaqiruvlari e’tiborsiz qoldiriladi.
 func (s *Streamer) Close() {
+	if s.err != nil {
+		return
+	}
 	s.s.Close()
 }
@@ -0,0 +1,36 @@
+package mfcc
+
+import (
+	"errors"
+
+	"github.com/BaxtiyorUrolov/go-mfcc/internal"
+)
+
+// LogMelSpectrogram har bir ramka uchun log-Mel filtrlar banki energiyalarini (fbank) qaytaradi.
+// Natija [ramkalar][NumFilters] o‘lchamida; ramkalash va oyna MFCC bilan bir xil.
+func (p *Processor) LogMelSpectrogram(audio []float32) ([][]float32, error) {
+	return p.output(audio, internal.OutputLogMel)
+}
+
+// MelSpectrogram har bir ramka uchun chiziqli Mel energiyalarini qaytaradi.
+func (p *Processor) MelSpectrogram(audio []float32) ([][]float32, error) {
+	return p.output(audio, internal.OutputMel)
+}
+
+// PowerSpectrogram har bir ramka uchun power spectrumni (|X|², FFT uzunligi/2+1 ta qiymat) qaytaradi.
+func (p *Processor) PowerSpectrogram(audio []float32) ([][]float32, error) {
+	return p.output(audio, internal.OutputPowerSpectrum)
+}
+
+// MagnitudeSpectrogram har bir ramka uchun magnituda spektrini (|X|) qaytaradi.
+func (p *Processor) MagnitudeSpectrogram(audio []float32) ([][]float32, error) {
+	return p.output(audio, internal.OutputMagnitudeSpectrum)
+}
+
+// output - Berilgan turdagi spektral matritsani hisoblash
+func (p *Processor) output(audio []float32, kind internal.OutputKind) ([][]float32, error) {
+	if len(audio) == 0 {
+		return nil, errors.New("bo‘sh audio kirishi")
+	}
+	return p.proc.ProcessOutput(audio, kind)
+}
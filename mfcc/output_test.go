@@ -0,0 +1,70 @@
+package mfcc
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSpectrogramOutputs(t *testing.T) {
+	cfg := DefaultConfig()
+	processor := newTestProcessor(t, cfg)
+	audio := noiseSignal(8000, 7)
+
+	mfccs, err := processor.Process(audio)
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+	power, err := processor.PowerSpectrogram(audio)
+	if err != nil {
+		t.Fatalf("PowerSpectrogram xatolik: %v", err)
+	}
+	magnitude, err := processor.MagnitudeSpectrogram(audio)
+	if err != nil {
+		t.Fatalf("MagnitudeSpectrogram xatolik: %v", err)
+	}
+	mel, err := processor.MelSpectrogram(audio)
+	if err != nil {
+		t.Fatalf("MelSpectrogram xatolik: %v", err)
+	}
+	logMel, err := processor.LogMelSpectrogram(audio)
+	if err != nil {
+		t.Fatalf("LogMelSpectrogram xatolik: %v", err)
+	}
+
+	for _, m := range [][][]float32{power, magnitude, mel, logMel} {
+		if len(m) != len(mfccs) {
+			t.Fatalf("ramkalar soni %d, MFCC da %d", len(m), len(mfccs))
+		}
+	}
+	for i := range mfccs {
+		if len(power[i]) != cfg.FrameLength/2+1 || len(mel[i]) != cfg.NumFilters || len(logMel[i]) != cfg.NumFilters {
+			t.Fatalf("ramka %d: noto‘g‘ri o‘lchamlar", i)
+		}
+		for j := range power[i] {
+			if math.Abs(float64(magnitude[i][j]*magnitude[i][j]-power[i][j])) > 1e-3*math.Max(1, float64(power[i][j])) {
+				t.Fatalf("ramka %d bin %d: |X|² %v != %v", i, j, magnitude[i][j]*magnitude[i][j], power[i][j])
+			}
+		}
+		for j := range mel[i] {
+			if want := float32(math.Log(float64(mel[i][j]) + 1e-6)); math.Abs(float64(logMel[i][j]-want)) > 1e-5 {
+				t.Fatalf("ramka %d filtr %d: log-Mel %v, kutilgan %v", i, j, logMel[i][j], want)
+			}
+		}
+
+		// Log-Mel ning ortonormal DCT-II si MFCC ga teng bo‘lishi kerak
+		n := float64(cfg.NumFilters)
+		for k := 0; k < cfg.NumCoefficients; k++ {
+			var sum float64
+			for m, v := range logMel[i] {
+				sum += float64(v) * math.Cos(math.Pi*float64(k)*(float64(m)+0.5)/n)
+			}
+			scale := math.Sqrt(2 / n)
+			if k == 0 {
+				scale = math.Sqrt(1 / n)
+			}
+			if math.Abs(sum*scale-float64(mfccs[i][k])) > 1e-3 {
+				t.Fatalf("ramka %d koeffitsient %d: DCT(log-Mel) %v, MFCC %v", i, k, sum*scale, mfccs[i][k])
+			}
+		}
+	}
+}
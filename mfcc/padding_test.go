@@ -0,0 +1,116 @@
+package mfcc
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestPaddingFrameCounts(t *testing.T) {
+	// DefaultConfig: FrameLength=512, HopLength=256; 0 - ErrAudioTooShort kutiladi
+	lengths := []int{1, 200, 512, 1000}
+	cases := []struct {
+		padding PaddingMode
+		padMode PadMode
+		want    []int
+	}{
+		{PaddingNone, "", []int{0, 0, 1, 2}},
+		{PaddingTail, "", []int{1, 1, 1, 3}},
+		{PaddingCenter, PadReflect, []int{1, 1, 3, 4}},
+		{PaddingCenter, PadEdge, []int{1, 1, 3, 4}},
+		{PaddingCenter, PadConstant, []int{1, 1, 3, 4}},
+		{PaddingKaldi, "", []int{0, 1, 2, 4}},
+	}
+
+	for _, tc := range cases {
+		t.Run(fmt.Sprintf("%s_%s", tc.padding, tc.padMode), func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Padding = tc.padding
+			cfg.PadMode = tc.padMode
+			processor := newTestProcessor(t, cfg)
+
+			for i, n := range lengths {
+				mfccs, err := processor.Process(testSignal(n))
+				if tc.want[i] == 0 {
+					if !errors.Is(err, ErrAudioTooShort) {
+						t.Errorf("%d namuna: ErrAudioTooShort kutilgan edi, olindi %v", n, err)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("%d namuna: Process xatolik: %v", n, err)
+				}
+				if len(mfccs) != tc.want[i] {
+					t.Errorf("%d namuna: %d ramka, kutilgan %d", n, len(mfccs), tc.want[i])
+				}
+				for _, frame := range mfccs {
+					for _, v := range frame {
+						if math.IsNaN(float64(v)) || math.IsInf(float64(v), 0) {
+							t.Fatalf("%d namuna: noto‘g‘ri qiymat %v", n, v)
+						}
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestFrameTimes(t *testing.T) {
+	cfg := DefaultConfig()
+	hop := float64(cfg.HopLength) / float64(cfg.SampleRate)
+	half := float64(cfg.FrameLength/2) / float64(cfg.SampleRate)
+
+	for _, tc := range []struct {
+		padding PaddingMode
+		first   float64
+	}{
+		{PaddingNone, half},
+		{PaddingCenter, 0},
+		{PaddingKaldi, hop / 2},
+	} {
+		cfg.Padding = tc.padding
+		times := newTestProcessor(t, cfg).FrameTimes(3)
+		for i, got := range times {
+			if want := tc.first + float64(i)*hop; math.Abs(got-want) > 1e-12 {
+				t.Errorf("%s: ramka %d vaqti %v, kutilgan %v", tc.padding, i, got, want)
+			}
+		}
+	}
+}
+
+func TestStreamerPaddingMatchesProcess(t *testing.T) {
+	for _, padding := range []PaddingMode{PaddingNone, PaddingTail, PaddingCenter, PaddingKaldi} {
+		cfg := DefaultConfig()
+		cfg.Padding = padding
+		processor := newTestProcessor(t, cfg)
+
+		audio := testSignal(1000)
+		want, err := processor.Process(audio)
+		if err != nil {
+			t.Fatalf("%s: Process xatolik: %v", padding, err)
+		}
+
+		streamer := processor.NewStreamer()
+		if err := streamer.Err(); err != nil {
+			t.Fatalf("%s: NewStreamer xatolik: %v", padding, err)
+		}
+		// Flush dan keyin yangi oqim yana chap chetdan to‘ldiriladi, shuning uchun ikkala oqim ham Process ga teng
+		// (ortiqcha ramka bo‘lsa ikkinchi oqim siljiydi)
+		for round := 0; round < 2; round++ {
+			for start := 0; start < len(audio); start += 300 {
+				streamer.Write(audio[start:min(start+300, len(audio))])
+			}
+			streamer.Flush()
+			got := make([][]float32, len(want))
+			for i := range got {
+				got[i] = streamer.Read()
+			}
+			assertSameFrames(t, got, want)
+		}
+		streamer.Close()
+		if err := streamer.Err(); err != nil {
+			t.Errorf("%s: Err: %v", padding, err)
+		}
+	}
+}
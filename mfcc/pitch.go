@@ -0,0 +1,9 @@
+package mfcc
+
+// PitchMethod - Fundamental chastotani (f0) aniqlash algoritmi
+type PitchMethod string
+
+const (
+	PitchYIN  PitchMethod = "yin"  // YIN: chegaradan past birinchi CMNDF minimumi, har bir ramka alohida
+	PitchPYIN PitchMethod = "pyin" // Ehtimoliy YIN: ovozlilik ehtimoli va ramkalar bo‘yicha Viterbi silliqlash (librosa `pyin`)
+)
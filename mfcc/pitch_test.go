@@ -0,0 +1,138 @@
+package mfcc
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+)
+
+// harmonicSignal - f0 asosiy chastotali, kuchli ikkinchi garmonikali ton (oktava xatosiga moyil signal)
+func harmonicSignal(f0 float64, n, sampleRate int) []float32 {
+	audio := make([]float32, n)
+	for i := range audio {
+		t := float64(i) / float64(sampleRate)
+		v := 0.3*math.Sin(2*math.Pi*f0*t) + 0.4*math.Sin(2*math.Pi*2*f0*t) + 0.15*math.Sin(2*math.Pi*3*f0*t)
+		audio[i] = float32(v)
+	}
+	return audio
+}
+
+func TestPitchTracking(t *testing.T) {
+	const sampleRate = 16000
+	// 150 Hz, sokin shovqin va raqamli jimjitlik, 220 Hz - har biri 0.5 soniya
+	segment := sampleRate / 2
+	audio := harmonicSignal(150, segment, sampleRate)
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < segment; i++ {
+		v := float32(0)
+		if i < segment/2 {
+			v = float32(1e-3 * (rng.Float64()*2 - 1))
+		}
+		audio = append(audio, v)
+	}
+	audio = append(audio, harmonicSignal(220, segment, sampleRate)...)
+
+	for _, method := range []PitchMethod{PitchYIN, PitchPYIN} {
+		t.Run(string(method), func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.PitchMethod = method
+			features, err := newTestProcessor(t, cfg).ProcessFeatures(audio)
+			if err != nil {
+				t.Fatalf("ProcessFeatures xatolik: %v", err)
+			}
+
+			for i, f := range features {
+				// Segmentlar chegarasini kesib o‘tuvchi ramkalar tekshirilmaydi
+				start, end := i*cfg.HopLength, i*cfg.HopLength+cfg.FrameLength
+				var want float64
+				switch {
+				case end <= segment:
+					want = 150
+				case start >= segment && end <= 2*segment:
+					want = 0
+				case start >= 2*segment:
+					want = 220
+				default:
+					continue
+				}
+				if want == 0 {
+					if f.Voiced || f.Pitch != 0 {
+						t.Errorf("ramka %d: shovqin yoki jimjitlik ovozli deb topildi (%v Hz)", i, f.Pitch)
+					}
+					continue
+				}
+				if !f.Voiced || math.Abs(float64(f.Pitch)-want) > 0.01*want {
+					t.Errorf("ramka %d: pitch %v (ovozli %v), kutilgan %v Hz", i, f.Pitch, f.Voiced, want)
+				}
+				if f.VoicedProb < 0.5 {
+					t.Errorf("ramka %d: ovozlilik ehtimoli %v", i, f.VoicedProb)
+				}
+			}
+		})
+	}
+}
+
+func TestPitchIgnoresPreEmphasis(t *testing.T) {
+	// Pitch xom signaldan aniqlanadi, shuning uchun pre-emphasis koeffitsienti natijaga ta’sir qilmaydi
+	audio := harmonicSignal(90, 16000, 16000)
+	for _, method := range []PitchMethod{PitchYIN, PitchPYIN} {
+		cfg := DefaultConfig()
+		cfg.PitchMethod = method
+		cfg.Padding = PaddingTail
+		cfg.Features = FeaturePitch
+		cfg.PreEmphasis = 0
+		want, err := newTestProcessor(t, cfg).ProcessFeatures(audio)
+		if err != nil {
+			t.Fatalf("ProcessFeatures xatolik: %v", err)
+		}
+		cfg.PreEmphasis = 0.97
+		got, err := newTestProcessor(t, cfg).ProcessFeatures(audio)
+		if err != nil {
+			t.Fatalf("ProcessFeatures xatolik: %v", err)
+		}
+		for i := range want {
+			if got[i].Pitch != want[i].Pitch || got[i].Voiced != want[i].Voiced || got[i].VoicedProb != want[i].VoicedProb {
+				t.Fatalf("%s ramka %d: pitch %v, pre-emphasis siz %v", method, i, got[i].Pitch, want[i].Pitch)
+			}
+		}
+	}
+}
+
+func TestPitchConfigValidation(t *testing.T) {
+	cases := map[string]func(*Config){
+		"method":    func(c *Config) { c.PitchMethod = "crepe" },
+		"fmin":      func(c *Config) { c.PitchFmin = 40 }, // 400 namunalik davr 512 ramkaga sig‘maydi
+		"range":     func(c *Config) { c.PitchFmin, c.PitchFmax = 200, 100 },
+		"nyquist":   func(c *Config) { c.PitchFmax = 8000 },
+		"threshold": func(c *Config) { c.PitchThreshold = 1 },
+	}
+	for name, configure := range cases {
+		cfg := DefaultConfig()
+		configure(&cfg)
+		if err := cfg.Validate(); err == nil {
+			t.Errorf("%s: xatolik kutilgan edi", name)
+		}
+	}
+}
+
+func BenchmarkPitch(b *testing.B) {
+	audio := harmonicSignal(150, 16000, 16000)
+	for _, method := range []PitchMethod{PitchYIN, PitchPYIN} {
+		b.Run(string(method), func(b *testing.B) {
+			cfg := DefaultConfig()
+			cfg.PitchMethod = method
+			cfg.Parallel = false
+			processor, err := NewProcessor(cfg)
+			if err != nil {
+				b.Fatalf("NewProcessor xatolik: %v", err)
+			}
+			defer processor.Close()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := processor.ProcessFeatures(audio); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+package mfcc
+
+import "fmt"
+
+// Preset - Boshqa kutubxonalar bilan bir xil natija beruvchi tayyor sozlamalar to‘plami
+type Preset string
+
+const (
+	// CompatLibrosa - `librosa.feature.mfcc` standart parametrlari: sr=22050, n_fft=2048, hop=512,
+	// davriy Hann oynasi, center=True (reflect), Slaney Mel shkalasi va normalizatsiyasi,
+	// power_to_db (top_db=80) va ortonormal DCT-II, 20 koeffitsient.
+	// Eslatma: librosa 0.10 dan boshlab `stft` standart pad_mode "constant"; shu versiyalar bilan
+	// solishtirishda PadMode ni PadConstant ga o‘zgartiring.
+	CompatLibrosa Preset = "librosa"
+	// CompatKaldi - Kaldi `compute-mfcc-feats` standart parametrlari (DefaultKaldiOptions): 25/10 ms ramkalar,
+	// Povey oynasi, 23 Mel filtr, C0 o‘rniga energiya, lifter 22. Boshqa Kaldi opsiyalari uchun KaldiOptions dan foydalaning.
+	CompatKaldi Preset = "kaldi"
+)
+
+// PresetConfig tanlangan moslik rejimi uchun konfiguratsiyani qaytaradi.
+// Natijadagi maydonlarni (masalan, SampleRate) kerak bo‘lsa o‘zgartirish mumkin.
+func PresetConfig(preset Preset) (Config, error) {
+	switch preset {
+	case CompatLibrosa:
+		cfg := DefaultConfig()
+		cfg.SampleRate = 22050
+		cfg.FrameLength = 2048
+		cfg.HopLength = 512
+		cfg.NumCoefficients = 20
+		cfg.NumFilters = 128
+		cfg.WindowType = Hanning
+		cfg.PeriodicWindow = true
+		cfg.PreEmphasis = 0
+		cfg.MelScale = MelScaleSlaney
+		cfg.MelNorm = MelNormSlaney
+		cfg.Padding = PaddingCenter
+		cfg.PadMode = PadReflect
+		cfg.LogMode = LogDB
+		cfg.TopDB = 80
+		return cfg, nil
+	case CompatKaldi:
+		return DefaultKaldiOptions().Config()
+	default:
+		return Config{}, fmt.Errorf("noma’lum preset %q", preset)
+	}
+}
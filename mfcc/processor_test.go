@@ -1,6 +1,9 @@
 package mfcc
 
 import (
+	"errors"
+	"math"
+	"sync"
 	"testing"
 )
 
@@ -18,6 +21,24 @@ func TestNewProcessor(t *testing.T) {
 	}
 }
 
+func TestConfigValidateShape(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.NumCoefficients = 40
+	cfg.NumFilters = 26
+	if err := cfg.Validate(); err == nil {
+		t.Error("NumCoefficients > NumFilters uchun xatolik kutilgan edi")
+	}
+
+	cfg = DefaultConfig()
+	cfg.WindowType = "hann"
+	if err := cfg.Validate(); err == nil {
+		t.Error("noma’lum oyna turi uchun xatolik kutilgan edi")
+	}
+	if _, err := NewProcessor(cfg); err == nil {
+		t.Error("NewProcessor noma’lum oyna turi uchun xatolik qaytarishi kerak edi")
+	}
+}
+
 func TestProcess(t *testing.T) {
 	cfg := DefaultConfig()
 	processor, err := NewProcessor(cfg)
@@ -88,3 +109,226 @@ func BenchmarkProcessBatch(b *testing.B) {
 		}
 	}
 }
+
+// BenchmarkProcessBatchSkewed - Uzunliklari juda farq qiladigan partiya: bir nechta uzun fayl va ko‘p qisqa fayllar.
+// Ishchilar orasida yuk bir tekis taqsimlanmasa, uzun fayllarni olgan ishchi qolganlari bo‘sh turganda ishlaydi.
+// "static" - avvalgi ProcessBatch dagi ketma-ket bo‘laklarga statik bo‘lish, "queue" - umumiy navbat.
+func BenchmarkProcessBatchSkewed(b *testing.B) {
+	modes := []struct {
+		name     string
+		parallel bool
+		run      func(processor *Processor, audios [][]float32, workers int) error
+	}{
+		{"sequential", false, processBatchQueue},
+		{"static", true, processBatchStatic},
+		{"queue", true, processBatchQueue},
+	}
+	for _, mode := range modes {
+		b.Run(mode.name, func(b *testing.B) {
+			cfg := DefaultConfig()
+			cfg.MaxConcurrency = 8
+			cfg.Parallel = mode.parallel
+			processor, err := NewProcessor(cfg)
+			if err != nil {
+				b.Fatalf("NewProcessor xatolik: %v", err)
+			}
+			defer processor.Close()
+
+			// Uzun fayllar boshida: statik bo‘lishda ularning barchasi bitta ishchiga tushadi
+			audios := make([][]float32, 32)
+			samples := 0
+			for i := range audios {
+				n := cfg.SampleRate / 2
+				if i < 4 {
+					n = 10 * cfg.SampleRate
+				}
+				audios[i] = testSignal(n)
+				samples += n
+			}
+
+			b.SetBytes(int64(4 * samples))
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := mode.run(processor, audios, cfg.MaxConcurrency); err != nil {
+					b.Fatalf("ProcessBatch xatolik: %v", err)
+				}
+			}
+		})
+	}
+}
+
+// processBatchQueue - ProcessBatch (umumiy navbatli rejalashtiruvchi)
+func processBatchQueue(processor *Processor, audios [][]float32, _ int) error {
+	_, err := processor.ProcessBatch(audios)
+	return err
+}
+
+// processBatchStatic - Avvalgi ProcessBatch: har bir ishchi partiyaning ketma-ket bo‘lagini oladi
+func processBatchStatic(processor *Processor, audios [][]float32, numWorkers int) error {
+	chunkSize := (len(audios) + numWorkers - 1) / numWorkers
+	errs := make([]error, numWorkers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < numWorkers; i++ {
+		start := min(i*chunkSize, len(audios))
+		end := min(start+chunkSize, len(audios))
+		wg.Add(1)
+		go func(i, start, end int) {
+			defer wg.Done()
+			for _, audio := range audios[start:end] {
+				if _, err := processor.Process(audio); err != nil {
+					errs[i] = err
+					return
+				}
+			}
+		}(i, start, end)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+func TestProcessDeltas(t *testing.T) {
+	cfg := DefaultConfig()
+	base, err := NewProcessor(cfg)
+	if err != nil {
+		t.Fatalf("NewProcessor xatolik: %v", err)
+	}
+	defer base.Close()
+
+	cfg.DeltaOrder = 2
+	processor, err := NewProcessor(cfg)
+	if err != nil {
+		t.Fatalf("NewProcessor xatolik: %v", err)
+	}
+	defer processor.Close()
+
+	audio := testSignal(cfg.FrameLength * 8)
+	static, err := base.Process(audio)
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+	got, err := processor.Process(audio)
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+
+	n := cfg.NumCoefficients
+	clamp := func(i int) int {
+		if i < 0 {
+			return 0
+		}
+		if i >= len(static) {
+			return len(static) - 1
+		}
+		return i
+	}
+	for i, vec := range got {
+		if len(vec) != 3*n {
+			t.Fatalf("ramka %d: vektor uzunligi %d, kutilgan %d", i, len(vec), 3*n)
+		}
+		for k := 0; k < n; k++ {
+			if vec[k] != static[i][k] {
+				t.Fatalf("ramka %d: MFCC o‘zgargan", i)
+			}
+			// N=2 uchun d_t = (c[t+1]-c[t-1] + 2*(c[t+2]-c[t-2])) / 10
+			want := (static[clamp(i+1)][k] - static[clamp(i-1)][k] +
+				2*(static[clamp(i+2)][k]-static[clamp(i-2)][k])) / 10
+			if diff := vec[n+k] - want; diff > 1e-4 || diff < -1e-4 {
+				t.Fatalf("ramka %d koeffitsient %d: delta %v, kutilgan %v", i, k, vec[n+k], want)
+			}
+		}
+	}
+}
+
+func TestStreamerDeltasMatchProcess(t *testing.T) {
+	cfg := DefaultConfig()
+	cfg.DeltaOrder = 2
+	cfg.Parallel = false
+	processor, err := NewProcessor(cfg)
+	if err != nil {
+		t.Fatalf("NewProcessor xatolik: %v", err)
+	}
+	defer processor.Close()
+
+	audio := testSignal(cfg.FrameLength + 19*cfg.HopLength)
+	want, err := processor.Process(audio)
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+
+	streamer := processor.NewStreamer()
+	if err := streamer.Err(); err != nil {
+		t.Fatalf("NewStreamer xatolik: %v", err)
+	}
+	defer streamer.Close()
+	for i := 0; i < len(audio); i += 300 {
+		end := i + 300
+		if end > len(audio) {
+			end = len(audio)
+		}
+		streamer.Write(audio[i:end])
+	}
+	streamer.Flush()
+
+	for i := range want {
+		got := streamer.Read()
+		if len(got) != len(want[i]) {
+			t.Fatalf("ramka %d: uzunlik %d, kutilgan %d", i, len(got), len(want[i]))
+		}
+		for k := range got {
+			if diff := got[k] - want[i][k]; diff > 1e-4 || diff < -1e-4 {
+				t.Fatalf("ramka %d qiymat %d: %v, kutilgan %v", i, k, got[k], want[i][k])
+			}
+		}
+	}
+}
+
+func TestStreamerWriteAfterClose(t *testing.T) {
+	processor := newTestProcessor(t, DefaultConfig())
+	streamer := processor.NewStreamer()
+	if err := streamer.Err(); err != nil {
+		t.Fatalf("NewStreamer xatolik: %v", err)
+	}
+	streamer.Write(testSignal(4096))
+	streamer.Close()
+
+	// Close dan keyingi chaqiruvlar panic qilmasligi kerak
+	streamer.Write(testSignal(1024))
+	streamer.Flush()
+	streamer.Close()
+}
+
+func TestCepLifter(t *testing.T) {
+	// HTK/Kaldi lifter koeffitsientlari, L=22: 1 + 11*sin(pi*n/22)
+	reference := []float64{1, 2.565463, 4.099058, 5.569565, 6.947049, 8.203468, 9.313245,
+		10.253789, 11.005952, 11.554423, 11.888036, 12, 11.888036}
+
+	cfg := DefaultConfig()
+	cfg.Parallel = false
+	plain := newTestProcessor(t, cfg)
+	cfg.CepLifter = 22
+	liftered := newTestProcessor(t, cfg)
+
+	// 440 Hz sinus signali
+	audio := make([]float32, cfg.SampleRate/4)
+	for i := range audio {
+		audio[i] = float32(0.5 * math.Sin(2*math.Pi*440*float64(i)/float64(cfg.SampleRate)))
+	}
+	want, err := plain.Process(audio)
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+	got, err := liftered.Process(audio)
+	if err != nil {
+		t.Fatalf("Process xatolik: %v", err)
+	}
+
+	for i := range want {
+		for n, w := range reference {
+			expected := float64(want[i][n]) * w
+			if math.Abs(float64(got[i][n])-expected) > 1e-4*math.Max(1, math.Abs(expected)) {
+				t.Fatalf("ramka %d koeffitsient %d: %v, kutilgan %v", i, n, got[i][n], expected)
+			}
+		}
+	}
+}
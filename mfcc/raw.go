@@ -0,0 +1,177 @@
+package mfcc
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"os"
+)
+
+// RawEncoding sarlavhasiz (headerless) audio namunalarining kodlanishi.
+type RawEncoding string
+
+const (
+	RawS8    RawEncoding = "s8"    // 8 bitli ishorali PCM
+	RawU8    RawEncoding = "u8"    // 8 bitli ishorasiz PCM (128 atrofida)
+	RawS16LE RawEncoding = "s16le" // 16 bitli ishorali PCM, little-endian
+	RawS16BE RawEncoding = "s16be" // 16 bitli ishorali PCM, big-endian
+	RawS24LE RawEncoding = "s24le" // 24 bitli ishorali PCM, little-endian (3 bayt)
+	RawS32LE RawEncoding = "s32le" // 32 bitli ishorali PCM, little-endian
+	RawF32LE RawEncoding = "f32le" // 32 bitli IEEE float, little-endian
+	RawMuLaw RawEncoding = "mulaw" // G.711 µ-law (Shimoliy Amerika/Yaponiya telefoniyasi)
+	RawALaw  RawEncoding = "alaw"  // G.711 A-law (Yevropa telefoniyasi)
+)
+
+// RawPCMOptions sarlavhasiz audio parametrlari: ular fayl ichida yo‘qligi uchun oldindan ma’lum bo‘lishi kerak.
+type RawPCMOptions struct {
+	SampleRate int         // Namunalar tezligi (Hz, masalan, telefoniya uchun 8000)
+	Channels   int         // Kanallar soni (0 bo‘lsa 1); namunalar interleaved
+	Encoding   RawEncoding // Namunalar kodlanishi
+}
+
+// G.711 dekodlash jadvallari (bayt -> 16 bitli chiziqli qiymat)
+var (
+	muLawTable = makeG711Table(muLawToLinear)
+	aLawTable  = makeG711Table(aLawToLinear)
+)
+
+// DecodeRawPCM r dagi sarlavhasiz interleaved namunalarni opts bo‘yicha [-1, 1] oralig‘idagi AudioBuffer ga o‘qiydi.
+// G.711 µ-law/A-law namunalari 16 bitli chiziqli shkalaga kengaytiriladi. Oxirgi to‘liq bo‘lmagan blok tashlanadi.
+func DecodeRawPCM(r io.Reader, opts RawPCMOptions) (*AudioBuffer, error) {
+	if opts.Channels == 0 {
+		opts.Channels = 1
+	}
+	if opts.SampleRate <= 0 || opts.Channels < 0 {
+		return nil, fmt.Errorf("noto‘g‘ri xom PCM parametrlari: %d Hz, %d kanal", opts.SampleRate, opts.Channels)
+	}
+	decode, sampleSize, err := rawSampleDecoder(opts.Encoding)
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("xom PCM ma’lumotlarini o‘qishda xatolik: %w", err)
+	}
+	return &AudioBuffer{SampleRate: opts.SampleRate, Channels: deinterleave(data, opts.Channels, sampleSize, decode)}, nil
+}
+
+// LoadRawPCM sarlavhasiz audio faylni o‘qib, mono float32 signal qaytaradi (ko‘p kanallar o‘rtachalanadi).
+// Namunalar tezligi opts.SampleRate ga teng; kerak bo‘lsa Resample yoki Processor.ProcessChannels dan foydalaning.
+func LoadRawPCM(filename string, opts RawPCMOptions) ([]float32, error) {
+	file, err := os.Open(filename)
+	if err != nil {
+		return nil, fmt.Errorf("audio faylni ochishda xatolik: %w", err)
+	}
+	defer file.Close()
+
+	buf, err := DecodeRawPCM(file, opts)
+	if err != nil {
+		return nil, err
+	}
+	return buf.Downmix(), nil
+}
+
+// rawSampleDecoder - Kodlanish bo‘yicha namuna dekoderi va namuna o‘lchami (bayt)
+func rawSampleDecoder(encoding RawEncoding) (func(b []byte) float32, int, error) {
+	switch encoding {
+	case RawS8:
+		return pcmSampleDecoder(1, false), 1, nil
+	case RawU8:
+		return func(b []byte) float32 { return float32(int(b[0])-128) / 128 }, 1, nil
+	case RawS16LE:
+		return pcmSampleDecoder(2, false), 2, nil
+	case RawS16BE:
+		return pcmSampleDecoder(2, true), 2, nil
+	case RawS24LE:
+		return pcmSampleDecoder(3, false), 3, nil
+	case RawS32LE:
+		return pcmSampleDecoder(4, false), 4, nil
+	case RawF32LE:
+		return func(b []byte) float32 { return math.Float32frombits(binary.LittleEndian.Uint32(b)) }, 4, nil
+	case RawMuLaw:
+		return g711SampleDecoder(&muLawTable), 1, nil
+	case RawALaw:
+		return g711SampleDecoder(&aLawTable), 1, nil
+	default:
+		return nil, 0, fmt.Errorf("noma’lum xom PCM kodlanishi %q", encoding)
+	}
+}
+
+// pcmSampleDecoder - size baytli (1-4) ishorali butun sonli namunani o‘qib, konteynerning to‘liq shkalasiga bo‘lish
+func pcmSampleDecoder(size int, bigEndian bool) func(b []byte) float32 {
+	full := float64(uint64(1) << (8*size - 1))
+	return func(b []byte) float32 {
+		// Baytlarni 32 bitli so‘zning yuqori qismiga yig‘ib, ishorani arifmetik siljitish bilan tiklash
+		var word uint32
+		for i := 0; i < size; i++ {
+			if bigEndian {
+				word |= uint32(b[i]) << (24 - 8*i)
+			} else {
+				word |= uint32(b[i]) << (32 - 8*size + 8*i)
+			}
+		}
+		return float32(float64(int32(word)>>(32-8*size)) / full)
+	}
+}
+
+// g711SampleDecoder - G.711 jadvali orqali bitta baytni [-1, 1] oralig‘idagi qiymatga o‘girish
+func g711SampleDecoder(table *[256]int16) func(b []byte) float32 {
+	return func(b []byte) float32 { return float32(table[b[0]]) / 32768 }
+}
+
+// deinterleave - Interleaved baytlarni kanallarga ajratib dekodlash (oxirgi to‘liq bo‘lmagan blok tashlanadi)
+func deinterleave(data []byte, numChannels, sampleSize int, decode func(b []byte) float32) [][]float32 {
+	blockAlign := numChannels * sampleSize
+	numFrames := len(data) / blockAlign
+
+	channels := make([][]float32, numChannels)
+	for c := range channels {
+		channels[c] = make([]float32, numFrames)
+	}
+	for i := 0; i < numFrames; i++ {
+		block := data[i*blockAlign:]
+		for c := range channels {
+			channels[c][i] = decode(block[c*sampleSize:])
+		}
+	}
+	return channels
+}
+
+// muLawToLinear - ITU-T G.711 µ-law baytini 16 bitli chiziqli qiymatga kengaytirish (diapazon ±32124)
+func muLawToLinear(u byte) int16 {
+	u = ^u
+	t := (int(u&0x0F)<<3 + 0x84) << ((u & 0x70) >> 4)
+	if u&0x80 != 0 {
+		return int16(0x84 - t)
+	}
+	return int16(t - 0x84)
+}
+
+// aLawToLinear - ITU-T G.711 A-law baytini 16 bitli chiziqli qiymatga kengaytirish (diapazon ±32256)
+func aLawToLinear(a byte) int16 {
+	a ^= 0x55
+	t := int(a&0x0F) << 4
+	switch seg := (a & 0x70) >> 4; seg {
+	case 0:
+		t += 8
+	case 1:
+		t += 0x108
+	default:
+		t = (t + 0x108) << (seg - 1)
+	}
+	if a&0x80 != 0 {
+		return int16(t)
+	}
+	return int16(-t)
+}
+
+// makeG711Table - Barcha 256 bayt uchun kengaytirish jadvali
+func makeG711Table(expand func(byte) int16) [256]int16 {
+	var table [256]int16
+	for i := range table {
+		table[i] = expand(byte(i))
+	}
+	return table
+}
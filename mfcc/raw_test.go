@@ -0,0 +1,125 @@
+package mfcc
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestG711Expansion(t *testing.T) {
+	// ITU-T G.711 jadvallaridagi chegaraviy qiymatlar
+	muLaw := map[byte]int16{0x00: -32124, 0x80: 32124, 0xFF: 0, 0x7F: 0, 0x0F: -16764, 0xEF: 132}
+	for in, want := range muLaw {
+		if got := muLawToLinear(in); got != want {
+			t.Errorf("µ-law 0x%02X: %d, kutilgan %d", in, got, want)
+		}
+	}
+	aLaw := map[byte]int16{0xD5: 8, 0x55: -8, 0xAA: 32256, 0x2A: -32256, 0xC5: 264}
+	for in, want := range aLaw {
+		if got := aLawToLinear(in); got != want {
+			t.Errorf("A-law 0x%02X: %d, kutilgan %d", in, got, want)
+		}
+	}
+
+	// Ishora bitidan tashqari kod o‘sishi bilan amplituda monoton o‘zgaradi
+	for i := 1; i < 128; i++ {
+		if muLawTable[0x80|i] >= muLawTable[0x80|(i-1)] || muLawTable[i] <= muLawTable[i-1] {
+			t.Fatalf("µ-law jadvali %d da monoton emas", i)
+		}
+	}
+}
+
+func TestDecodeRawPCM(t *testing.T) {
+	cases := []struct {
+		encoding RawEncoding
+		data     []byte
+	}{
+		{RawS8, []byte{0x80, 0xC0, 0x00, 0x40}},
+		{RawU8, []byte{0x00, 0x40, 0x80, 0xC0}},
+		{RawS16LE, []byte{0x00, 0x80, 0x00, 0xC0, 0x00, 0x00, 0x00, 0x40}},
+		{RawS16BE, []byte{0x80, 0x00, 0xC0, 0x00, 0x00, 0x00, 0x40, 0x00}},
+		{RawS24LE, []byte{0, 0, 0x80, 0, 0, 0xC0, 0, 0, 0, 0, 0, 0x40}},
+		{RawS32LE, []byte{0, 0, 0, 0x80, 0, 0, 0, 0xC0, 0, 0, 0, 0, 0, 0, 0, 0x40}},
+		{RawF32LE, []byte{0, 0, 0x80, 0xBF, 0, 0, 0, 0xBF, 0, 0, 0, 0, 0, 0, 0, 0x3F}},
+	}
+	want := []float32{-1, -0.5, 0, 0.5}
+	for _, tc := range cases {
+		// Stereo: juft namunalar chap, toq namunalar o‘ng kanalga; oxiridagi to‘liq bo‘lmagan blok tashlanadi
+		buf, err := DecodeRawPCM(bytes.NewReader(append(tc.data, 0x01)), RawPCMOptions{SampleRate: 8000, Channels: 2, Encoding: tc.encoding})
+		if err != nil {
+			t.Fatalf("%s: DecodeRawPCM xatolik: %v", tc.encoding, err)
+		}
+		for c, channel := range buf.Channels {
+			for i, got := range channel {
+				if expected := want[i*2+c]; got != expected {
+					t.Errorf("%s kanal %d namuna %d: %v, kutilgan %v", tc.encoding, c, i, got, expected)
+				}
+			}
+		}
+	}
+
+	// WAV ichidagi G.711 (format tegi 6) xuddi shu jadvallar bilan kengaytiriladi
+	channels, _, err := DecodeWAV(bytes.NewReader(buildWAV(wavSpec{tag: WAVFormatALaw, bits: 8, channels: 1}, []byte{0xD5, 0xAA})))
+	if err != nil || channels[0][0] != 8.0/32768 || channels[0][1] != 32256.0/32768 {
+		t.Errorf("A-law WAV: %v, %v", channels, err)
+	}
+
+	if _, err := DecodeRawPCM(bytes.NewReader(nil), RawPCMOptions{SampleRate: 8000, Encoding: "adpcm"}); err == nil {
+		t.Error("noma’lum kodlanish uchun xatolik kutilgan edi")
+	}
+	if _, err := DecodeRawPCM(bytes.NewReader(nil), RawPCMOptions{Encoding: RawMuLaw}); err == nil {
+		t.Error("SampleRate siz xatolik kutilgan edi")
+	}
+}
+
+func TestLoadRawPCMTelephony(t *testing.T) {
+	// 8 kHz µ-law telefoniya yozuvi: 1 soniyalik 440 Hz ton
+	data := make([]byte, 8000)
+	for i := range data {
+		v := 0.5 * math.Sin(2*math.Pi*440*float64(i)/8000)
+		data[i] = muLawEncode(int16(v * 32767))
+	}
+	path := filepath.Join(t.TempDir(), "call.ulaw")
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		t.Fatalf("faylni yozishda xatolik: %v", err)
+	}
+
+	audio, err := LoadRawPCM(path, RawPCMOptions{SampleRate: 8000, Encoding: RawMuLaw})
+	if err != nil {
+		t.Fatalf("LoadRawPCM xatolik: %v", err)
+	}
+	for i, got := range audio {
+		want := 0.5 * math.Sin(2*math.Pi*440*float64(i)/8000)
+		// µ-law kvantlash xatoligi amplitudaga nisbatan ~3%
+		if math.Abs(float64(got)-want) > 0.02 {
+			t.Fatalf("namuna %d: %v, kutilgan %v", i, got, want)
+		}
+	}
+
+	cfg := DefaultConfig()
+	cfg.SampleRate = 8000
+	cfg.FrameLength, cfg.HopLength = 200, 80
+	features, err := newTestProcessor(t, cfg).Process(audio)
+	if err != nil || len(features) != (8000-200)/80+1 {
+		t.Errorf("Process: %d ramka, %v", len(features), err)
+	}
+}
+
+// muLawEncode - Test uchun G.711 µ-law kodlovchisi (Sun g711.c dagi linear2ulaw)
+func muLawEncode(pcm int16) byte {
+	const bias, clip = 0x84, 32635
+	v := int(pcm)
+	mask := byte(0xFF)
+	if v < 0 {
+		v = -v
+		mask = 0x7F
+	}
+	v = min(v, clip) + bias
+	seg := 0
+	for v>>(seg+8) != 0 && seg < 7 {
+		seg++
+	}
+	return byte(seg<<4|(v>>(seg+3))&0x0F) ^ mask
+}
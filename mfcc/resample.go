@@ -0,0 +1,35 @@
+package mfcc
+
+import (
+	"fmt"
+
+	"github.com/BaxtiyorUrolov/go-mfcc/internal"
+)
+
+// ResampleQuality namunalar tezligini o‘zgartirish sifati.
+type ResampleQuality string
+
+const (
+	ResampleFast   ResampleQuality = "fast"   // 16 nol kesishish, tez (resampy `kaiser_fast`)
+	ResampleMedium ResampleQuality = "medium" // 32 nol kesishish
+	ResampleHigh   ResampleQuality = "high"   // 64 nol kesishish, standart (resampy `kaiser_best`)
+)
+
+// Resample signalni fromRate dan toRate ga polifazali Kaiser oynali sinc filtr bilan o‘tkazadi.
+// Bo‘sh quality ResampleHigh ni bildiradi. Natija uzunligi ceil(len(signal) * toRate / fromRate).
+func Resample(signal []float32, fromRate, toRate int, quality ResampleQuality) ([]float32, error) {
+	return internal.Resample(signal, fromRate, toRate, internal.ResampleQuality(quality))
+}
+
+// Resample barcha kanallarni toRate ga o‘tkazilgan yangi AudioBuffer qaytaradi.
+func (b *AudioBuffer) Resample(toRate int, quality ResampleQuality) (*AudioBuffer, error) {
+	out := &AudioBuffer{SampleRate: toRate, Channels: make([][]float32, len(b.Channels))}
+	for c, channel := range b.Channels {
+		resampled, err := Resample(channel, b.SampleRate, toRate, quality)
+		if err != nil {
+			return nil, fmt.Errorf("kanal %d ni qayta namunalashda xatolik: %w", c, err)
+		}
+		out.Channels[c] = resampled
+	}
+	return out, nil
+}
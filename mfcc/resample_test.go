@@ -0,0 +1,76 @@
+package mfcc
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+// sine - Berilgan chastota va tezlikdagi sinus signal
+func sine(freq float64, rate, n int) []float32 {
+	out := make([]float32, n)
+	for i := range out {
+		out[i] = float32(0.5 * math.Sin(2*math.Pi*freq*float64(i)/float64(rate)))
+	}
+	return out
+}
+
+// interiorError - Chetlardan margin namuna uzoqlikdagi maksimal xatolik
+func interiorError(got, want []float32, margin int) float64 {
+	var maxErr float64
+	for i := margin; i < len(want)-margin && i < len(got); i++ {
+		maxErr = math.Max(maxErr, math.Abs(float64(got[i]-want[i])))
+	}
+	return maxErr
+}
+
+func TestResampleSine(t *testing.T) {
+	rates := [][2]int{{44100, 16000}, {8000, 48000}, {22050, 16000}, {48000, 44100}, {16000, 22050}}
+	tolerances := map[ResampleQuality]float64{ResampleFast: 5e-3, ResampleMedium: 1e-3, ResampleHigh: 2e-4}
+
+	for _, rate := range rates {
+		for quality, tol := range tolerances {
+			t.Run(fmt.Sprintf("%d_%d_%s", rate[0], rate[1], quality), func(t *testing.T) {
+				from, to := rate[0], rate[1]
+				got, err := Resample(sine(1000, from, from/2), from, to, quality)
+				if err != nil {
+					t.Fatalf("Resample xatolik: %v", err)
+				}
+				if want := (from/2*to + from - 1) / from; len(got) != want {
+					t.Fatalf("uzunlik %d, kutilgan %d", len(got), want)
+				}
+				if maxErr := interiorError(got, sine(1000, to, len(got)), to/50); maxErr > tol {
+					t.Errorf("maksimal xatolik %g > %g", maxErr, tol)
+				}
+			})
+		}
+	}
+}
+
+func TestResampleAntiAliasing(t *testing.T) {
+	// 7 kHz ton 8 kHz ga o‘tkazilganda (yangi Nyquist 4 kHz) deyarli butunlay so‘nishi kerak
+	got, err := Resample(sine(7000, 16000, 16000), 16000, 8000, ResampleHigh)
+	if err != nil {
+		t.Fatalf("Resample xatolik: %v", err)
+	}
+	var peak float64
+	for _, v := range got[200 : len(got)-200] {
+		peak = math.Max(peak, math.Abs(float64(v)))
+	}
+	if peak > 1e-3 {
+		t.Errorf("aliasing amplitudasi %g, kutilgan < 1e-3", peak)
+	}
+}
+
+func TestResampleInvalid(t *testing.T) {
+	if _, err := Resample([]float32{1}, 0, 16000, ResampleHigh); err == nil {
+		t.Error("nol tezlik uchun xatolik kutilgan edi")
+	}
+	if _, err := Resample([]float32{1}, 8000, 16000, "ultra"); err == nil {
+		t.Error("noma’lum sifat uchun xatolik kutilgan edi")
+	}
+	same, err := Resample([]float32{1, 2, 3}, 16000, 16000, "")
+	if err != nil || len(same) != 3 || same[2] != 3 {
+		t.Errorf("bir xil tezlik: %v, %v", same, err)
+	}
+}
@@ -0,0 +1,343 @@
+#!/usr/bin/env python3
+"""Generate tiny FLAC fixtures (and matching 16/24-bit WAV references) for flac_test.go.
+
+No FLAC tool is needed: this is a small dependency-free FLAC encoder that writes
+each subframe type (CONSTANT, VERBATIM, FIXED, LPC), every stereo decorrelation
+mode, wasted bits, Rice/Rice2 residuals with escape partitions, and a STREAMINFO
+block with the MD5 of the decoded samples. The same integer samples are written
+to <name>.wav with the stdlib wave module, so the Go test compares two decoders:
+
+    cd testdata && python3 gen_flac_fixtures.py
+"""
+import hashlib
+import math
+import random
+import struct
+import wave
+
+
+def crc8(data):
+    crc = 0
+    for b in data:
+        crc ^= b
+        for _ in range(8):
+            crc = ((crc << 1) ^ 0x07) & 0xFF if crc & 0x80 else (crc << 1) & 0xFF
+    return crc
+
+
+def crc16(data):
+    crc = 0
+    for b in data:
+        crc ^= b << 8
+        for _ in range(8):
+            crc = ((crc << 1) ^ 0x8005) & 0xFFFF if crc & 0x8000 else (crc << 1) & 0xFFFF
+    return crc
+
+
+class BitWriter:
+    def __init__(self):
+        self.bits = []
+
+    def write(self, value, n):
+        for i in range(n - 1, -1, -1):
+            self.bits.append((value >> i) & 1)
+
+    def write_signed(self, value, n):
+        self.write(value & ((1 << n) - 1), n)
+
+    def unary(self, q):
+        self.bits.extend([0] * q)
+        self.bits.append(1)
+
+    def align(self):
+        while len(self.bits) % 8:
+            self.bits.append(0)
+
+    def tobytes(self):
+        assert len(self.bits) % 8 == 0
+        out = bytearray()
+        for i in range(0, len(self.bits), 8):
+            byte = 0
+            for bit in self.bits[i:i + 8]:
+                byte = byte << 1 | bit
+            out.append(byte)
+        return bytes(out)
+
+
+def utf8_number(n):
+    if n < 0x80:
+        return bytes([n])
+    for nbytes, limit in ((2, 1 << 11), (3, 1 << 16), (4, 1 << 21), (5, 1 << 26), (6, 1 << 31), (7, 1 << 36)):
+        if n < limit:
+            break
+    tail = []
+    for _ in range(nbytes - 1):
+        tail.insert(0, 0x80 | (n & 0x3F))
+        n >>= 6
+    return bytes([((0xFF << (8 - nbytes)) & 0xFF) | n] + tail)
+
+
+def signed_bits(v):
+    return (v if v >= 0 else ~v).bit_length() + 1
+
+
+def fold(r):
+    return 2 * r if r >= 0 else -2 * r - 1
+
+
+def rice_cost(part, k):
+    return sum((fold(r) >> k) + 1 + k for r in part)
+
+
+FIXED_COEFFS = [[], [1], [2, -1], [3, -3, 1], [4, -6, 4, -1]]
+
+
+def predict(x, coeffs, shift=0):
+    order = len(coeffs)
+    residual = []
+    for i in range(order, len(x)):
+        pred = sum(c * x[i - 1 - j] for j, c in enumerate(coeffs))
+        residual.append(x[i] - (pred >> shift))
+    return residual
+
+
+def lpc_coeffs(x, order, precision):
+    """Levinson-Durbin on the autocorrelation, then libFLAC-style quantization."""
+    n = len(x)
+    r = [sum(x[i] * x[i - lag] for i in range(lag, n)) for lag in range(order + 1)]
+    r[0] = r[0] * 1.0001 + 1
+    a = [0.0] * order
+    err = r[0]
+    for i in range(order):
+        acc = r[i + 1] - sum(a[j] * r[i - j] for j in range(i))
+        k = acc / err
+        new = a[:]
+        new[i] = k
+        for j in range(i):
+            new[j] = a[j] - k * a[i - 1 - j]
+        a = new
+        err *= 1 - k * k
+    cmax = max(abs(c) for c in a) or 1.0
+    shift = max(0, min(15, precision - 1 - math.frexp(cmax)[1]))
+    lim = 1 << (precision - 1)
+    q = [max(-lim, min(lim - 1, round(c * (1 << shift)))) for c in a]
+    return q, shift
+
+
+def write_residual(bw, residual, block_size, order, part_order, escapes):
+    parts, start = [], 0
+    for p in range(1 << part_order):
+        size = (block_size >> part_order) - (order if p == 0 else 0)
+        parts.append(residual[start:start + size])
+        start += size
+    params = [None if p in escapes else min(range(31), key=lambda k: rice_cost(part, k))
+              for p, part in enumerate(parts)]
+    method = 1 if any(k is not None and k >= 15 for k in params) else 0
+    pbits = 4 + method
+    bw.write(method, 2)
+    bw.write(part_order, 4)
+    for part, k in zip(parts, params):
+        if k is None:
+            raw = max((signed_bits(v) for v in part), default=0)
+            bw.write((1 << pbits) - 1, pbits)
+            bw.write(raw, 5)
+            for v in part:
+                bw.write_signed(v, raw)
+            continue
+        bw.write(k, pbits)
+        for v in part:
+            u = fold(v)
+            bw.unary(u >> k)
+            bw.write(u & ((1 << k) - 1), k)
+
+
+def write_subframe(bw, x, bps, kind):
+    wasted = 0
+    if any(x):
+        while all(v % (2 << wasted) == 0 for v in x):
+            wasted += 1
+    x = [v >> wasted for v in x]
+    bps -= wasted
+
+    name = kind[0]
+    typ = {"constant": 0, "verbatim": 1}.get(name)
+    if name == "fixed":
+        typ = 8 + kind[1]
+    elif name == "lpc":
+        typ = 31 + kind[1]
+    bw.write(0, 1)
+    bw.write(typ, 6)
+    if wasted:
+        bw.write(1, 1)
+        bw.unary(wasted - 1)
+    else:
+        bw.write(0, 1)
+
+    if name == "constant":
+        assert len(set(x)) == 1
+        bw.write_signed(x[0], bps)
+    elif name == "verbatim":
+        for v in x:
+            bw.write_signed(v, bps)
+    elif name == "fixed":
+        _, order, part_order, escapes = kind
+        for v in x[:order]:
+            bw.write_signed(v, bps)
+        write_residual(bw, predict(x, FIXED_COEFFS[order]), len(x), order, part_order, escapes)
+    else:
+        _, order, precision, part_order, escapes = kind
+        q, shift = lpc_coeffs(x, order, precision)
+        for v in x[:order]:
+            bw.write_signed(v, bps)
+        bw.write(precision - 1, 4)
+        bw.write_signed(shift, 5)
+        for c in q:
+            bw.write_signed(c, precision)
+        write_residual(bw, predict(x, q, shift), len(x), order, part_order, escapes)
+
+
+BLOCK_CODES = {192: 1, 576: 2, 1152: 3, 2304: 4, 4608: 5, 256: 8, 512: 9, 1024: 10, 2048: 11, 4096: 12,
+               8192: 13, 16384: 14, 32768: 15}
+RATE_CODES = {88200: 1, 176400: 2, 192000: 3, 8000: 4, 16000: 5, 22050: 6, 24000: 7, 32000: 8, 44100: 9,
+              48000: 10, 96000: 11}
+SIZE_CODES = {8: 1, 12: 2, 16: 4, 20: 5, 24: 6, 32: 7}
+
+
+def encode_frame(number, channels, rate, bps, rate_code, assignment, kinds):
+    block = len(channels[0])
+    hdr = BitWriter()
+    hdr.write(0x3FFE, 14)
+    hdr.write(0, 1)
+    hdr.write(0, 1)  # fixed blocking strategy
+    bs_code = BLOCK_CODES.get(block, 6 if block <= 256 else 7)
+    hdr.write(bs_code, 4)
+    hdr.write(rate_code, 4)
+    hdr.write(len(channels) - 1 if assignment is None else assignment, 4)
+    hdr.write(SIZE_CODES[bps], 3)
+    hdr.write(0, 1)
+    for b in utf8_number(number):
+        hdr.write(b, 8)
+    if bs_code == 6:
+        hdr.write(block - 1, 8)
+    elif bs_code == 7:
+        hdr.write(block - 1, 16)
+    if rate_code == 12:
+        hdr.write(rate // 1000, 8)
+    elif rate_code in (13, 14):
+        hdr.write(rate if rate_code == 13 else rate // 10, 16)
+    header = hdr.tobytes()
+    header += bytes([crc8(header)])
+
+    subs = [list(ch) for ch in channels]
+    sub_bps = [bps] * len(subs)
+    if assignment is not None:
+        left, right = subs
+        side = [l - r for l, r in zip(left, right)]
+        if assignment == 8:
+            subs, sub_bps = [left, side], [bps, bps + 1]
+        elif assignment == 9:
+            subs, sub_bps = [side, right], [bps + 1, bps]
+        else:
+            subs, sub_bps = [[(l + r) >> 1 for l, r in zip(left, right)], side], [bps, bps + 1]
+
+    bw = BitWriter()
+    for x, sbps, kind in zip(subs, sub_bps, kinds):
+        write_subframe(bw, x, sbps, kind)
+    bw.align()
+    frame = header + bw.tobytes()
+    return frame + struct.pack(">H", crc16(frame))
+
+
+def encode(channels, rate, bps, block, frames, rate_code):
+    """frames: one (assignment, [kind per channel]) per block."""
+    total = len(channels[0])
+    out = []
+    for n, (assignment, kinds) in enumerate(frames):
+        chunk = [ch[n * block:(n + 1) * block] for ch in channels]
+        out.append(encode_frame(n, chunk, rate, bps, rate_code, assignment, kinds))
+    assert len(frames) * block >= total > (len(frames) - 1) * block
+
+    width = (bps + 7) // 8
+    md5 = hashlib.md5()
+    for i in range(total):
+        for ch in channels:
+            md5.update((ch[i] & ((1 << (8 * width)) - 1)).to_bytes(width, "little"))
+
+    info = struct.pack(">HH", block, block)
+    info += min(len(f) for f in out).to_bytes(3, "big") + max(len(f) for f in out).to_bytes(3, "big")
+    packed = rate << 44 | (len(channels) - 1) << 41 | (bps - 1) << 36 | total
+    info += packed.to_bytes(8, "big") + md5.digest()
+
+    data = b"fLaC"
+    data += bytes([0]) + len(info).to_bytes(3, "big") + info
+    padding = bytes(16)  # last metadata block: PADDING
+    data += bytes([0x80 | 1]) + len(padding).to_bytes(3, "big") + padding
+    return data + b"".join(out)
+
+
+def write_wav(path, channels, rate, bps):
+    width = bps // 8
+    with wave.open(path, "wb") as w:
+        w.setnchannels(len(channels))
+        w.setsampwidth(width)
+        w.setframerate(rate)
+        frames = bytearray()
+        for i in range(len(channels[0])):
+            for ch in channels:
+                frames += (ch[i] & ((1 << bps) - 1)).to_bytes(width, "little")
+        w.writeframes(bytes(frames))
+
+
+def tone(n, rate, freqs, amp, noise, rng):
+    return [int(amp * sum(math.sin(2 * math.pi * f * i / rate) for f in freqs) / len(freqs)
+                + rng.uniform(-noise, noise)) for i in range(n)]
+
+
+def main():
+    rng = random.Random(14)
+
+    # mono16: FIXED, LPC (escape partition), CONSTANT (jimjitlik), VERBATIM; oxirgi blok 16 bitli o‘lcham bilan
+    x = tone(4000, 16000, [220, 1330], 12000, 300, rng)
+    x[2304:3456] = [0] * 1152
+    frames = [
+        (None, [("fixed", 2, 0, ())]),
+        (None, [("lpc", 8, 12, 2, (1,))]),
+        (None, [("constant",)]),
+        (None, [("verbatim",)]),
+    ]
+    with open("mono16.flac", "wb") as f:
+        f.write(encode([x], 16000, 16, 1152, frames, 5))
+    write_wav("mono16.wav", [x], 16000, 16)
+
+    # stereo16: barcha kanal juftlash rejimlari, FIXED 0..4 va LPC
+    left = tone(3756, 44100, [440, 3100], 9000, 500, rng)
+    right = [int(0.6 * v) + rng.randint(-200, 200) for v in left]
+    frames = [
+        (None, [("fixed", 0, 1, ()), ("fixed", 1, 2, ())]),
+        (8, [("lpc", 12, 13, 4, ()), ("fixed", 3, 3, (2,))]),
+        (9, [("fixed", 4, 2, ()), ("lpc", 6, 10, 0, ())]),
+        (10, [("lpc", 10, 14, 1, ()), ("fixed", 2, 0, ())]),
+    ]
+    with open("stereo16.flac", "wb") as f:
+        f.write(encode([left, right], 44100, 16, 1152, frames, 9))
+    write_wav("stereo16.wav", [left, right], 44100, 16)
+
+    # stereo24: 12 kHz (8 bitli kHz kodi), wasted bits, Rice2 (katta shovqin), LPC 32-tartib, 8 bitli oxirgi blok
+    left = [v & ~7 for v in tone(1636, 12000, [300, 2500], 4_000_000, 100_000, rng)]
+    right = [v + rng.randint(-300_000, 300_000) & ~7 for v in left]
+    frames = [
+        (None, [("lpc", 32, 15, 3, ()), ("fixed", 1, 2, ())]),
+        (10, [("lpc", 16, 15, 2, ()), ("verbatim",)]),
+        (8, [("fixed", 2, 1, ()), ("lpc", 4, 8, 0, (0,))]),
+        (9, [("fixed", 3, 2, ()), ("lpc", 24, 12, 3, ())]),
+        (None, [("fixed", 4, 1, ()), ("fixed", 2, 0, ())]),
+        (10, [("lpc", 2, 15, 2, ()), ("fixed", 1, 0, ())]),
+        (None, [("verbatim",), ("fixed", 2, 0, ())]),
+    ]
+    with open("stereo24.flac", "wb") as f:
+        f.write(encode([left, right], 12000, 24, 256, frames, 12))
+    write_wav("stereo24.wav", [left, right], 12000, 24)
+
+
+if __name__ == "__main__":
+    main()
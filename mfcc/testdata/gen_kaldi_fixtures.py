@@ -0,0 +1,133 @@
+#!/usr/bin/env python3
+"""Generate golden Kaldi MFCC fixtures for the KaldiOptions mode (kaldi_mfcc.json).
+
+Each case is written to a 16-bit WAV file and run through Kaldi's
+`compute-mfcc-feats` with --dither=0, which must be on PATH. The git revision of
+the Kaldi checkout is recorded in the "kaldi_revision" field, and TestKaldiGolden
+rejects fixtures without it:
+
+    export PATH=$KALDI_ROOT/src/featbin:$PATH
+    python3 gen_kaldi_fixtures.py > kaldi_mfcc.json
+"""
+import json
+import math
+import os
+import shutil
+import struct
+import subprocess
+import sys
+import tempfile
+import wave
+
+DEFAULTS = dict(
+    sample_frequency=16000,
+    frame_shift=10.0,
+    frame_length=25.0,
+    dither=0.0,
+    preemphasis_coefficient=0.97,
+    remove_dc_offset=True,
+    window_type="povey",
+    round_to_power_of_two=True,
+    snip_edges=True,
+    num_mel_bins=23,
+    low_freq=20.0,
+    high_freq=0.0,
+    num_ceps=13,
+    use_energy=True,
+    energy_floor=0.0,
+    raw_energy=True,
+    cepstral_lifter=22.0,
+)
+
+CASES = [
+    dict(num_samples=8000),
+    dict(
+        num_samples=7000,
+        snip_edges=False,
+        window_type="hamming",
+        round_to_power_of_two=False,
+        raw_energy=False,
+        energy_floor=1.0,
+        num_mel_bins=40,
+        num_ceps=20,
+        low_freq=50.0,
+        high_freq=-400.0,
+        cepstral_lifter=0.0,
+    ),
+    dict(
+        num_samples=4000,
+        sample_frequency=8000,
+        window_type="hanning",
+        remove_dc_offset=False,
+        use_energy=False,
+        num_mel_bins=15,
+        preemphasis_coefficient=0.0,
+    ),
+]
+
+
+def signal(sr, num_samples):
+    """int16-valued test signal, reproduced in mfcc/kaldi_test.go."""
+    out = []
+    state = 12345
+    for n in range(num_samples):
+        t = n / sr
+        state = (state * 1103515245 + 12345) % 2147483648
+        noise = state % 401 - 200
+        sweep = 300.0 + 2000.0 * n / num_samples
+        v = 6000.0 * math.sin(2 * math.pi * 220.0 * t) + 3000.0 * math.sin(2 * math.pi * 1800.0 * t) + 800.0 * math.sin(2 * math.pi * sweep * t) + noise
+        out.append(float(math.floor(v + 0.5)))
+    return out
+
+
+def kaldi_revision(binary):
+    """git revision of the Kaldi checkout that built `binary` ($KALDI_ROOT or <root>/src/featbin/compute-mfcc-feats)."""
+    root = os.environ.get("KALDI_ROOT") or os.path.dirname(os.path.dirname(os.path.dirname(os.path.realpath(binary))))
+    try:
+        out = subprocess.run(["git", "-C", root, "rev-parse", "HEAD"], check=True, capture_output=True, text=True)
+    except (OSError, subprocess.CalledProcessError):
+        return ""
+    return out.stdout.strip()
+
+
+def with_kaldi(o, wav):
+    with tempfile.TemporaryDirectory() as tmp:
+        path = os.path.join(tmp, "utt.wav")
+        with wave.open(path, "wb") as w:
+            w.setnchannels(1)
+            w.setsampwidth(2)
+            w.setframerate(o["sample_frequency"])
+            w.writeframes(b"".join(struct.pack("<h", int(v)) for v in wav))
+        args = ["compute-mfcc-feats"]
+        for key, value in o.items():
+            if isinstance(value, bool):
+                value = str(value).lower()
+            args.append("--%s=%s" % (key.replace("_", "-"), value))
+        args += ["scp:echo utt %s |" % path, "ark,t:-"]
+        out = subprocess.run(args, check=True, capture_output=True, text=True).stdout
+    rows = out.split("[", 1)[1].split("]", 1)[0].strip().splitlines()
+    return [[float(v) for v in row.split()] for row in rows]
+
+
+def main():
+    binary = shutil.which("compute-mfcc-feats")
+    if binary is None:
+        sys.exit("Kaldi compute-mfcc-feats is required to generate kaldi_mfcc.json (add kaldi/src/featbin to PATH)")
+    revision = kaldi_revision(binary)
+    if not revision:
+        sys.exit("cannot determine the Kaldi git revision of %s (set KALDI_ROOT to the Kaldi checkout)" % binary)
+
+    cases = []
+    for case in CASES:
+        o = dict(DEFAULTS)
+        o.update(case)
+        num_samples = o.pop("num_samples")
+        feats = with_kaldi(o, signal(o["sample_frequency"], num_samples))
+        entry = dict(o, num_samples=num_samples)
+        entry["feats"] = [[float("%.7g" % v) for v in row] for row in feats]
+        cases.append(entry)
+    print(json.dumps({"kaldi_revision": revision, "cases": cases}))
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,64 @@
+#!/usr/bin/env python3
+"""Generate FLAC fixtures encoded by the reference libFLAC encoder (libflac.json + libflac_*.flac/.wav).
+
+The fixtures from gen_flac_fixtures.py are written by our own encoder, so they
+cannot catch a shared misreading of the format. Here each WAV is encoded with
+`flac -8` (the reference encoder picks its own block sizes, predictors and
+partitions), and the STREAMINFO MD5 reported by `metaflac --show-md5sum` is
+checked against the MD5 of the WAV samples. Both tools must be on PATH; their
+version is recorded in the "flac_version" field, and TestDecodeLibFLACFixtures
+rejects fixtures without it:
+
+    cd testdata && python3 gen_libflac_fixtures.py
+"""
+import hashlib
+import json
+import random
+import shutil
+import subprocess
+import sys
+import wave
+
+from gen_flac_fixtures import tone, write_wav
+
+CASES = [
+    dict(name="libflac_stereo16", sample_rate=44100, bits=16, num_samples=44100),
+    dict(name="libflac_mono24", sample_rate=48000, bits=24, num_samples=24000),
+]
+
+
+def wav_md5(path):
+    # libFLAC hashes interleaved little-endian samples, the same bytes as 16/24-bit WAV data
+    with wave.open(path, "rb") as w:
+        return hashlib.md5(w.readframes(w.getnframes())).hexdigest()
+
+
+def main():
+    for tool in ("flac", "metaflac"):
+        if shutil.which(tool) is None:
+            sys.exit("%s (reference libFLAC tools) is required to generate libflac.json" % tool)
+    version = subprocess.run(["flac", "--version"], check=True, capture_output=True, text=True).stdout.strip()
+
+    rng = random.Random(14)
+    files = []
+    for case in CASES:
+        amp = (1 << (case["bits"] - 1)) // 3
+        left = tone(case["num_samples"], case["sample_rate"], [220, 1330, 4100], amp, amp // 50, rng)
+        channels = [left]
+        if case["name"].startswith("libflac_stereo"):
+            channels.append([int(0.7 * v) + rng.randint(-amp // 40, amp // 40) for v in left])
+        wav, flac = case["name"] + ".wav", case["name"] + ".flac"
+        write_wav(wav, channels, case["sample_rate"], case["bits"])
+        subprocess.run(["flac", "-8", "--silent", "--force", "-o", flac, wav], check=True)
+        md5 = subprocess.run(["metaflac", "--show-md5sum", flac], check=True, capture_output=True, text=True).stdout.strip()
+        if md5 != wav_md5(wav):
+            sys.exit("%s: STREAMINFO MD5 %s does not match the WAV samples" % (flac, md5))
+        files.append(dict(case, channels=len(channels), md5=md5))
+
+    with open("libflac.json", "w") as f:
+        json.dump({"flac_version": version, "files": files}, f, indent=2)
+        f.write("\n")
+
+
+if __name__ == "__main__":
+    main()
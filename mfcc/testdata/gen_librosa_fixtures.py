@@ -0,0 +1,61 @@
+#!/usr/bin/env python3
+"""Generate golden MFCC / log-mel fixtures for the CompatLibrosa preset (librosa_mfcc.json).
+
+Values come from librosa.feature.mfcc and librosa.power_to_db with
+pad_mode="reflect". librosa must be installed; the installed version is
+recorded in the "librosa_version" field, and TestCompatLibrosaGolden rejects
+fixtures without it:
+
+    pip install librosa
+    python3 gen_librosa_fixtures.py > librosa_mfcc.json
+"""
+import json
+import math
+import struct
+import sys
+
+CASES = [
+    dict(sr=22050, n_fft=2048, hop_length=512, n_mels=128, n_mfcc=20, num_samples=5512),
+    dict(sr=16000, n_fft=512, hop_length=160, n_mels=40, n_mfcc=13, num_samples=4000),
+]
+TOP_DB = 80.0
+
+
+def f32(x):
+    return struct.unpack("f", struct.pack("f", x))[0]
+
+
+def signal(sr, num_samples):
+    """Test signal, reproduced in mfcc/librosa_test.go."""
+    out = []
+    for n in range(num_samples):
+        t = n / sr
+        sweep = 100.0 + 1500.0 * n / num_samples
+        v = 0.5 * math.sin(2 * math.pi * 440.0 * t) + 0.25 * math.sin(2 * math.pi * 1234.5 * t) + 0.1 * math.sin(2 * math.pi * sweep * t)
+        out.append(f32(v))
+    return out
+
+
+def main():
+    try:
+        import numpy as np
+        import librosa
+    except ImportError:
+        sys.exit("librosa is required to generate librosa_mfcc.json (pip install librosa)")
+
+    cases = []
+    for case in CASES:
+        y = np.asarray(signal(case["sr"], case["num_samples"]), dtype=np.float32)
+        kwargs = dict(sr=case["sr"], n_fft=case["n_fft"], hop_length=case["hop_length"], n_mels=case["n_mels"], pad_mode="reflect")
+        mfcc = librosa.feature.mfcc(y=y, n_mfcc=case["n_mfcc"], **kwargs)
+        log_mel = librosa.power_to_db(librosa.feature.melspectrogram(y=y, **kwargs), top_db=TOP_DB)
+
+        entry = dict(case)
+        entry["mfcc"] = [[float("%.7g" % v) for v in row] for row in mfcc.T.tolist()]
+        entry["log_mel"] = [[float("%.7g" % v) for v in row] for row in log_mel.T.tolist()]
+        cases.append(entry)
+    print(json.dumps({"librosa_version": librosa.__version__, "cases": cases}))
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,48 @@
+#!/usr/bin/env python3
+"""Generate golden Mel filterbank fixtures (mel_filters.json) with librosa.filters.mel.
+
+librosa must be installed; the installed version is recorded in the
+"librosa_version" field, and TestMelFilterBankGolden rejects fixtures without it:
+
+    pip install librosa
+    python3 gen_mel_fixtures.py > mel_filters.json
+"""
+import json
+import sys
+
+CASES = [
+    dict(sr=22050, n_fft=512, n_mels=40, fmin=0.0, fmax=None, htk=False, norm="slaney"),
+    dict(sr=16000, n_fft=512, n_mels=26, fmin=0.0, fmax=None, htk=True, norm=None),
+    dict(sr=16000, n_fft=400, n_mels=20, fmin=20.0, fmax=7600.0, htk=False, norm=1),
+    dict(sr=8000, n_fft=256, n_mels=23, fmin=64.0, fmax=3800.0, htk=True, norm=2),
+]
+
+
+def sparse(rows):
+    out = []
+    for row in rows:
+        nz = [j for j, w in enumerate(row) if w != 0]
+        if not nz:
+            out.append({"offset": 0, "weights": []})
+            continue
+        out.append({"offset": nz[0], "weights": [float("%.9g" % w) for w in row[nz[0] : nz[-1] + 1]]})
+    return out
+
+
+def main():
+    try:
+        import librosa
+    except ImportError:
+        sys.exit("librosa is required to generate mel_filters.json (pip install librosa)")
+
+    fixtures = []
+    for case in CASES:
+        entry = dict(case)
+        entry["norm"] = case["norm"] if case["norm"] is not None else "none"
+        entry["filters"] = sparse(librosa.filters.mel(**case).tolist())
+        fixtures.append(entry)
+    print(json.dumps({"librosa_version": librosa.__version__, "cases": fixtures}, indent=1))
+
+
+if __name__ == "__main__":
+    main()
@@ -0,0 +1,280 @@
+package mfcc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+)
+
+// WAV fmt bo‘lagidagi format teglari
+const (
+	WAVFormatPCM        uint16 = 0x0001 // Butun sonli PCM
+	WAVFormatIEEEFloat  uint16 = 0x0003 // IEEE 754 suzuvchi nuqtali namunalar
+	WAVFormatALaw       uint16 = 0x0006 // G.711 A-law (8 bit)
+	WAVFormatMuLaw      uint16 = 0x0007 // G.711 µ-law (8 bit)
+	WAVFormatExtensible uint16 = 0xFFFE // WAVE_FORMAT_EXTENSIBLE (haqiqiy format SubFormat GUID da)
+)
+
+// wavSubFormatSuffix - KSDATAFORMAT_SUBTYPE GUID ining format tegidan keyingi 14 bayti
+var wavSubFormatSuffix = []byte{0x00, 0x00, 0x00, 0x00, 0x10, 0x00, 0x80, 0x00, 0x00, 0xAA, 0x00, 0x38, 0x9B, 0x71}
+
+// maxFormatChunk - Format bo‘lagining (WAV fmt, AIFF COMM) ruxsat etilgan maksimal o‘lchami (buzilgan fayllardan himoya)
+const maxFormatChunk = 1 << 16
+
+// ErrInvalidWAV - Ma’lumotlar RIFF/WAVE tuzilmasiga mos kelmaydi
+var ErrInvalidWAV = errors.New("noto‘g‘ri WAV ma’lumotlari")
+
+// UnsupportedCodecError - WAV fayl qo‘llab-quvvatlanmaydigan kodek yoki bit chuqurligi bilan kodlangan
+type UnsupportedCodecError struct {
+	FormatTag     uint16 // fmt bo‘lagidagi (extensible bo‘lsa SubFormat dagi) format tegi
+	BitsPerSample int    // Namuna konteyneri o‘lchami (bit)
+}
+
+// Error - Xatolik matni
+func (e *UnsupportedCodecError) Error() string {
+	return fmt.Sprintf("qo‘llab-quvvatlanmaydigan WAV kodeki: format tegi 0x%04X, %d bit", e.FormatTag, e.BitsPerSample)
+}
+
+// WAVFormat - WAV faylning fmt bo‘lagidan o‘qilgan parametrlar
+type WAVFormat struct {
+	FormatTag     uint16 // Haqiqiy format tegi: WAVFormatPCM, WAVFormatIEEEFloat, WAVFormatALaw yoki WAVFormatMuLaw
+	Extensible    bool   // Sarlavha WAVE_FORMAT_EXTENSIBLE ko‘rinishida
+	Channels      int    // Kanallar soni
+	SampleRate    int    // Namunalar tezligi (Hz)
+	BitsPerSample int    // Namuna konteyneri o‘lchami (bit): 8, 16, 24, 32 yoki 64
+	ValidBits     int    // Konteynerdagi haqiqiy bitlar soni (masalan, 32 bitli konteynerda 24)
+	ChannelMask   uint32 // Karnaylar joylashuvi maskasi (faqat extensible sarlavhada)
+}
+
+// DecodeWAV r dan WAV (RIFF/WAVE) ma’lumotlarini o‘qiydi va har bir kanal namunalarini
+// [-1, 1] oralig‘ida qaytaradi ([kanal][namuna]). PCM 8/16/24/32 bit, IEEE float 32/64 bit, G.711 A-law/µ-law va
+// WAVE_FORMAT_EXTENSIBLE sarlavhalari qo‘llab-quvvatlanadi; boshqa kodeklar uchun *UnsupportedCodecError qaytariladi.
+// Hajmi 0 yoki 0xFFFFFFFF deb yozilgan (oqimdan yoki pipe orqali yozilgan) data bo‘lagi fayl oxirigacha o‘qiladi.
+func DecodeWAV(r io.Reader) ([][]float32, WAVFormat, error) {
+	format, size, err := readWAVHeader(r)
+	if err != nil {
+		return nil, format, err
+	}
+	data, err := io.ReadAll(io.LimitReader(r, size))
+	if err != nil {
+		return nil, format, fmt.Errorf("WAV ma’lumotlarini o‘qishda xatolik: %w", err)
+	}
+	channels, err := decodeWAVSamples(data, format)
+	return channels, format, err
+}
+
+// WAVReader WAV ma’lumotlarini data bo‘lagidan blok-blok o‘qiydi, shuning uchun ko‘p soatlik fayllarni
+// xotiraga to‘liq yuklamasdan qayta ishlash mumkin. Qo‘llab-quvvatlanadigan formatlar DecodeWAV bilan bir xil.
+type WAVReader struct {
+	format WAVFormat
+	data   io.Reader // data bo‘lagi
+	decode func(b []byte) float32
+	buf    []byte
+	eof    bool
+}
+
+// NewWAVReader r dan WAV sarlavhasini data bo‘lagi boshigacha o‘qiydi; namunalar Read orqali olinadi.
+func NewWAVReader(r io.Reader) (*WAVReader, error) {
+	format, size, err := readWAVHeader(r)
+	if err != nil {
+		return nil, err
+	}
+	decode, err := wavSampleDecoder(format)
+	if err != nil {
+		return nil, err
+	}
+	return &WAVReader{format: format, data: io.LimitReader(r, size), decode: decode}, nil
+}
+
+// Format fmt bo‘lagidan o‘qilgan parametrlarni qaytaradi.
+func (w *WAVReader) Format() WAVFormat {
+	return w.format
+}
+
+// Read navbatdagi namunalarni dst kanallariga ([kanal][namuna], len(dst) = Channels) o‘qiydi va
+// o‘qilgan namunalar sonini qaytaradi; har bir kanalga eng ko‘pi bilan min(len(dst[c])) namuna yoziladi.
+// Ma’lumotlar tugaganda 0, io.EOF qaytariladi. Oxirgi to‘liq bo‘lmagan blok tashlanadi.
+func (w *WAVReader) Read(dst [][]float32) (int, error) {
+	if len(dst) != w.format.Channels {
+		return 0, fmt.Errorf("%d kanal uchun bufer berilgan, faylda %d kanal", len(dst), w.format.Channels)
+	}
+	if w.eof {
+		return 0, io.EOF
+	}
+	numFrames := len(dst[0])
+	for _, channel := range dst[1:] {
+		numFrames = min(numFrames, len(channel))
+	}
+	sampleSize := w.format.BitsPerSample / 8
+	blockAlign := w.format.Channels * sampleSize
+	if cap(w.buf) < numFrames*blockAlign {
+		w.buf = make([]byte, numFrames*blockAlign)
+	}
+
+	n, err := io.ReadFull(w.data, w.buf[:numFrames*blockAlign])
+	switch {
+	case errors.Is(err, io.EOF) || errors.Is(err, io.ErrUnexpectedEOF):
+		w.eof = true
+	case err != nil:
+		return 0, fmt.Errorf("WAV ma’lumotlarini o‘qishda xatolik: %w", err)
+	}
+	numFrames = n / blockAlign
+	if numFrames == 0 && w.eof {
+		return 0, io.EOF
+	}
+	for i := 0; i < numFrames; i++ {
+		block := w.buf[i*blockAlign:]
+		for c := range dst {
+			dst[c][i] = w.decode(block[c*sampleSize:])
+		}
+	}
+	return numFrames, nil
+}
+
+// readWAVHeader - RIFF sarlavhasi va data bo‘lagigacha bo‘lgan bo‘laklarni o‘qish.
+// r data bo‘lagi boshida qoladi; data bo‘lagining sarlavhadagi o‘lchami qaytariladi (noma’lum bo‘lsa math.MaxInt64).
+func readWAVHeader(r io.Reader) (WAVFormat, int64, error) {
+	var header [12]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		return WAVFormat{}, 0, fmt.Errorf("%w: RIFF sarlavhasini o‘qib bo‘lmadi: %v", ErrInvalidWAV, err)
+	}
+	if string(header[0:4]) != "RIFF" || string(header[8:12]) != "WAVE" {
+		return WAVFormat{}, 0, fmt.Errorf("%w: RIFF/WAVE sarlavhasi topilmadi", ErrInvalidWAV)
+	}
+
+	var format WAVFormat
+	haveFormat := false
+	for {
+		var chunk [8]byte
+		if _, err := io.ReadFull(r, chunk[:]); err != nil {
+			if errors.Is(err, io.EOF) {
+				return format, 0, fmt.Errorf("%w: data bo‘lagi topilmadi", ErrInvalidWAV)
+			}
+			return format, 0, fmt.Errorf("%w: bo‘lak sarlavhasini o‘qib bo‘lmadi: %v", ErrInvalidWAV, err)
+		}
+		id := string(chunk[:4])
+		size := int64(binary.LittleEndian.Uint32(chunk[4:]))
+
+		switch id {
+		case "fmt ":
+			if size < 16 || size > maxFormatChunk {
+				return format, 0, fmt.Errorf("%w: fmt bo‘lagi o‘lchami %d", ErrInvalidWAV, size)
+			}
+			body := make([]byte, size+size&1)
+			if _, err := io.ReadFull(r, body); err != nil {
+				return format, 0, fmt.Errorf("%w: fmt bo‘lagini o‘qib bo‘lmadi: %v", ErrInvalidWAV, err)
+			}
+			var err error
+			if format, err = parseWAVFormat(body[:size]); err != nil {
+				return format, 0, err
+			}
+			haveFormat = true
+		case "data":
+			if !haveFormat {
+				return format, 0, fmt.Errorf("%w: data bo‘lagi fmt bo‘lagidan oldin kelgan", ErrInvalidWAV)
+			}
+			if size == 0 || size == math.MaxUint32 {
+				// Oqimdan yozilgan fayllarda o‘lcham oldindan ma’lum emas: fayl oxirigacha o‘qiladi
+				size = math.MaxInt64
+			}
+			return format, size, nil
+		default:
+			// Noma’lum bo‘laklarni (LIST, fact, cue va h.k.) o‘tkazib yuborish; toq o‘lchamdan keyin bitta to‘ldiruvchi bayt bor
+			if _, err := io.CopyN(io.Discard, r, size+size&1); err != nil {
+				return format, 0, fmt.Errorf("%w: %q bo‘lagini o‘tkazib bo‘lmadi: %v", ErrInvalidWAV, id, err)
+			}
+		}
+	}
+}
+
+// parseWAVFormat - fmt bo‘lagini tahlil qilish va formatning izchilligini tekshirish
+func parseWAVFormat(body []byte) (WAVFormat, error) {
+	le := binary.LittleEndian
+	format := WAVFormat{
+		FormatTag:  le.Uint16(body[0:2]),
+		Channels:   int(le.Uint16(body[2:4])),
+		SampleRate: int(le.Uint32(body[4:8])),
+	}
+	blockAlign := int(le.Uint16(body[12:14]))
+	bits := int(le.Uint16(body[14:16]))
+	if format.Channels == 0 || format.SampleRate == 0 {
+		return format, fmt.Errorf("%w: %d kanal, %d Hz", ErrInvalidWAV, format.Channels, format.SampleRate)
+	}
+
+	// Oddiy sarlavhada bits haqiqiy bitlar soni, konteyner esa butun baytlarga yaxlitlanadi
+	format.BitsPerSample = (bits + 7) / 8 * 8
+	format.ValidBits = bits
+	if format.FormatTag == WAVFormatExtensible {
+		if len(body) < 40 {
+			return format, fmt.Errorf("%w: extensible fmt bo‘lagi juda qisqa (%d bayt)", ErrInvalidWAV, len(body))
+		}
+		format.Extensible = true
+		format.BitsPerSample = bits
+		format.ValidBits = int(le.Uint16(body[18:20]))
+		format.ChannelMask = le.Uint32(body[20:24])
+		format.FormatTag = le.Uint16(body[24:26])
+		if !bytes.Equal(body[26:40], wavSubFormatSuffix) {
+			return format, &UnsupportedCodecError{FormatTag: WAVFormatExtensible, BitsPerSample: bits}
+		}
+		if format.ValidBits == 0 {
+			format.ValidBits = bits
+		}
+	}
+
+	if _, err := wavSampleDecoder(format); err != nil {
+		return format, err
+	}
+	if blockAlign != format.Channels*format.BitsPerSample/8 {
+		return format, fmt.Errorf("%w: block align %d, kutilgan %d", ErrInvalidWAV, blockAlign, format.Channels*format.BitsPerSample/8)
+	}
+	return format, nil
+}
+
+// wavSampleDecoder - Format bo‘yicha bitta namunani [-1, 1] oralig‘idagi float32 ga o‘giruvchi funksiya.
+// Haqiqiy bitlar konteynerning yuqori qismida joylashgani uchun konteynerning to‘liq shkalasiga bo‘linadi.
+func wavSampleDecoder(format WAVFormat) (func(b []byte) float32, error) {
+	le := binary.LittleEndian
+	switch format.FormatTag {
+	case WAVFormatPCM:
+		switch format.BitsPerSample {
+		case 8: // 8 bitli PCM ishorasiz, 128 atrofida
+			return func(b []byte) float32 { return float32(int(b[0])-128) / 128 }, nil
+		case 16:
+			return func(b []byte) float32 { return float32(int16(le.Uint16(b))) / 32768 }, nil
+		case 24:
+			return func(b []byte) float32 {
+				v := int32(uint32(b[0])<<8|uint32(b[1])<<16|uint32(b[2])<<24) >> 8
+				return float32(v) / 8388608
+			}, nil
+		case 32:
+			return func(b []byte) float32 { return float32(float64(int32(le.Uint32(b))) / 2147483648) }, nil
+		}
+	case WAVFormatALaw, WAVFormatMuLaw:
+		if format.BitsPerSample == 8 {
+			if format.FormatTag == WAVFormatALaw {
+				return g711SampleDecoder(&aLawTable), nil
+			}
+			return g711SampleDecoder(&muLawTable), nil
+		}
+	case WAVFormatIEEEFloat:
+		switch format.BitsPerSample {
+		case 32:
+			return func(b []byte) float32 { return math.Float32frombits(le.Uint32(b)) }, nil
+		case 64:
+			return func(b []byte) float32 { return float32(math.Float64frombits(le.Uint64(b))) }, nil
+		}
+	}
+	return nil, &UnsupportedCodecError{FormatTag: format.FormatTag, BitsPerSample: format.BitsPerSample}
+}
+
+// decodeWAVSamples - Interleaved data bo‘lagini kanallarga ajratib dekodlash (oxirgi to‘liq bo‘lmagan blok tashlanadi)
+func decodeWAVSamples(data []byte, format WAVFormat) ([][]float32, error) {
+	decode, err := wavSampleDecoder(format)
+	if err != nil {
+		return nil, err
+	}
+	return deinterleave(data, format.Channels, format.BitsPerSample/8, decode), nil
+}
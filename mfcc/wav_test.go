@@ -0,0 +1,211 @@
+package mfcc
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// wavSpec - Test uchun WAV fayl parametrlari
+type wavSpec struct {
+	tag        uint16
+	bits       int // Konteyner o‘lchami
+	validBits  int // Faqat extensible sarlavha uchun
+	extensible bool
+	channels   int
+}
+
+// buildWAV - Berilgan xom namunalar (interleaved, konteyner baytlari) bilan WAV fayl yaratish.
+// Sarlavhadan keyin toq o‘lchamli LIST bo‘lagi qo‘shiladi.
+func buildWAV(spec wavSpec, data []byte) []byte {
+	le := binary.LittleEndian
+	fmtChunk := make([]byte, 16, 40)
+	tag := spec.tag
+	if spec.extensible {
+		tag = WAVFormatExtensible
+	}
+	blockAlign := spec.channels * spec.bits / 8
+	le.PutUint16(fmtChunk[0:], tag)
+	le.PutUint16(fmtChunk[2:], uint16(spec.channels))
+	le.PutUint32(fmtChunk[4:], 44100)
+	le.PutUint32(fmtChunk[8:], uint32(44100*blockAlign))
+	le.PutUint16(fmtChunk[12:], uint16(blockAlign))
+	le.PutUint16(fmtChunk[14:], uint16(spec.bits))
+	if spec.extensible {
+		ext := make([]byte, 24)
+		le.PutUint16(ext[0:], 22)
+		le.PutUint16(ext[2:], uint16(spec.validBits))
+		le.PutUint32(ext[4:], 0x3)
+		le.PutUint16(ext[8:], spec.tag)
+		copy(ext[10:], wavSubFormatSuffix)
+		fmtChunk = append(fmtChunk, ext...)
+	}
+
+	var body bytes.Buffer
+	body.WriteString("WAVE")
+	writeChunk := func(id string, payload []byte) {
+		body.WriteString(id)
+		binary.Write(&body, le, uint32(len(payload)))
+		body.Write(payload)
+		if len(payload)%2 == 1 {
+			body.WriteByte(0)
+		}
+	}
+	writeChunk("fmt ", fmtChunk)
+	writeChunk("LIST", []byte("INFOx"))
+	writeChunk("data", data)
+
+	var out bytes.Buffer
+	out.WriteString("RIFF")
+	binary.Write(&out, le, uint32(body.Len()))
+	out.Write(body.Bytes())
+	return out.Bytes()
+}
+
+func TestDecodeWAVFormats(t *testing.T) {
+	le := binary.LittleEndian
+	// Har bir formatda [-1, -0.5, 0, 0.5] qiymatlari
+	want := []float32{-1, -0.5, 0, 0.5}
+
+	pcm24 := func(v int32) []byte { return []byte{byte(v), byte(v >> 8), byte(v >> 16)} }
+	var f32, f64, s16, s32, s32in24 []byte
+	for _, v := range want {
+		f32 = le.AppendUint32(f32, math.Float32bits(v))
+		f64 = le.AppendUint64(f64, math.Float64bits(float64(v)))
+		s16 = le.AppendUint16(s16, uint16(int16(v*32768)))
+		s32 = le.AppendUint32(s32, uint32(int32(float64(v)*2147483648)))
+		s32in24 = le.AppendUint32(s32in24, uint32(int32(v*8388608)<<8))
+	}
+	var s24 []byte
+	for _, v := range want {
+		s24 = append(s24, pcm24(int32(v*8388608))...)
+	}
+
+	cases := []struct {
+		name string
+		spec wavSpec
+		data []byte
+	}{
+		{"pcm8", wavSpec{tag: WAVFormatPCM, bits: 8, channels: 1}, []byte{0, 64, 128, 192}},
+		{"pcm16", wavSpec{tag: WAVFormatPCM, bits: 16, channels: 1}, s16},
+		{"pcm24", wavSpec{tag: WAVFormatPCM, bits: 24, channels: 1}, s24},
+		{"pcm32", wavSpec{tag: WAVFormatPCM, bits: 32, channels: 1}, s32},
+		{"float32", wavSpec{tag: WAVFormatIEEEFloat, bits: 32, channels: 1}, f32},
+		{"float64", wavSpec{tag: WAVFormatIEEEFloat, bits: 64, channels: 1}, f64},
+		{"extensible_pcm24_in_32", wavSpec{tag: WAVFormatPCM, bits: 32, validBits: 24, extensible: true, channels: 1}, s32in24},
+		{"extensible_float32", wavSpec{tag: WAVFormatIEEEFloat, bits: 32, validBits: 32, extensible: true, channels: 1}, f32},
+		// Stereo: juft namunalar chap, toq namunalar o‘ng kanalga tushadi
+		{"pcm16_stereo", wavSpec{tag: WAVFormatPCM, bits: 16, channels: 2}, s16},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			channels, format, err := DecodeWAV(bytes.NewReader(buildWAV(tc.spec, tc.data)))
+			if err != nil {
+				t.Fatalf("DecodeWAV xatolik: %v", err)
+			}
+			if format.SampleRate != 44100 || format.Channels != tc.spec.channels || format.FormatTag != tc.spec.tag {
+				t.Errorf("kutilmagan format: %+v", format)
+			}
+			for c, channel := range channels {
+				for i, got := range channel {
+					expected := want[i*len(channels)+c]
+					if math.Abs(float64(got-expected)) > 1e-6 {
+						t.Errorf("kanal %d namuna %d: %v, kutilgan %v", c, i, got, expected)
+					}
+				}
+			}
+		})
+	}
+}
+
+// TestDecodeWAVStreamedSize - Oqimdan yozilgan fayllarda data bo‘lagi o‘lchami 0 yoki 0xFFFFFFFF bo‘ladi
+func TestDecodeWAVStreamedSize(t *testing.T) {
+	var data []byte
+	for i := 0; i < 100; i++ {
+		data = binary.LittleEndian.AppendUint16(data, uint16(int16(i*300-15000)))
+	}
+	want, _, err := DecodeWAV(bytes.NewReader(buildWAV(wavSpec{tag: WAVFormatPCM, bits: 16, channels: 1}, data)))
+	if err != nil {
+		t.Fatalf("DecodeWAV xatolik: %v", err)
+	}
+
+	for _, size := range []uint32{0, math.MaxUint32} {
+		wav := buildWAV(wavSpec{tag: WAVFormatPCM, bits: 16, channels: 1}, data)
+		// data bo‘lagi oxirgi: uning o‘lchami namunalardan oldingi 4 baytda
+		binary.LittleEndian.PutUint32(wav[len(wav)-len(data)-4:], size)
+
+		got, _, err := DecodeWAV(bytes.NewReader(wav))
+		if err != nil {
+			t.Fatalf("o‘lcham %#x: DecodeWAV xatolik: %v", size, err)
+		}
+		assertSameSamples(t, got[0], want[0])
+
+		reader, err := NewWAVReader(bytes.NewReader(wav))
+		if err != nil {
+			t.Fatalf("o‘lcham %#x: NewWAVReader xatolik: %v", size, err)
+		}
+		buf := [][]float32{make([]float32, 2*len(want[0]))}
+		n, err := reader.Read(buf)
+		if err != nil {
+			t.Fatalf("o‘lcham %#x: Read xatolik: %v", size, err)
+		}
+		assertSameSamples(t, buf[0][:n], want[0])
+	}
+}
+
+// assertSameSamples - Ikki namunalar ketma-ketligining aynan tengligini tekshirish
+func assertSameSamples(t *testing.T, got, want []float32) {
+	t.Helper()
+	if len(got) != len(want) {
+		t.Fatalf("%d namuna, kutilgan %d", len(got), len(want))
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("namuna %d: %v, kutilgan %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDecodeWAVErrors(t *testing.T) {
+	var codecErr *UnsupportedCodecError
+	adpcm := buildWAV(wavSpec{tag: 0x0002, bits: 16, channels: 1}, []byte{0, 0})
+	if _, _, err := DecodeWAV(bytes.NewReader(adpcm)); !errors.As(err, &codecErr) || codecErr.FormatTag != 0x0002 {
+		t.Errorf("ADPCM uchun UnsupportedCodecError kutilgan edi, olindi %v", err)
+	}
+
+	pcm12 := buildWAV(wavSpec{tag: WAVFormatPCM, bits: 12, channels: 1}, []byte{0, 0})
+	if _, _, err := DecodeWAV(bytes.NewReader(pcm12)); err == nil {
+		t.Error("noto‘g‘ri block align uchun xatolik kutilgan edi")
+	}
+
+	for _, data := range [][]byte{nil, []byte("RIFF\x04\x00\x00\x00AVI "), []byte("RIFF\x04\x00\x00\x00WAVE")} {
+		if _, _, err := DecodeWAV(bytes.NewReader(data)); !errors.Is(err, ErrInvalidWAV) {
+			t.Errorf("%q uchun ErrInvalidWAV kutilgan edi, olindi %v", data, err)
+		}
+	}
+}
+
+func TestLoadAudioDownmix(t *testing.T) {
+	le := binary.LittleEndian
+	var data []byte
+	for _, v := range []int16{16384, -16384, 8192, 8192} {
+		data = le.AppendUint16(data, uint16(v))
+	}
+	path := filepath.Join(t.TempDir(), "stereo.wav")
+	if err := os.WriteFile(path, buildWAV(wavSpec{tag: WAVFormatPCM, bits: 16, channels: 2}, data), 0o644); err != nil {
+		t.Fatalf("faylni yozishda xatolik: %v", err)
+	}
+
+	audio, sampleRate, err := LoadAudio(path)
+	if err != nil {
+		t.Fatalf("LoadAudio xatolik: %v", err)
+	}
+	if sampleRate != 44100 || len(audio) != 2 || audio[0] != 0 || audio[1] != 0.25 {
+		t.Errorf("kutilmagan natija: %v Hz, %v", sampleRate, audio)
+	}
+}